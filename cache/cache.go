@@ -4,12 +4,15 @@ import (
 	"errors"
 	"github.com/ExpediaGroup/flyte-slack/types"
 	"github.com/rs/zerolog/log"
+	"sync"
 	"time"
 )
 
 var (
-	errNoInit        = errors.New("cache not initialized")
-	errNoSuchChannel = errors.New("can't find channel with such name")
+	errNoInit            = errors.New("cache not initialized")
+	errNoSuchChannel     = errors.New("can't find channel with such name")
+	errNoSuchUserGroup   = errors.New("can't find user group with such handle")
+	errNoSuchUserGroupId = errors.New("can't find user group with such id")
 )
 
 type Config struct {
@@ -19,22 +22,43 @@ type Config struct {
 // slackClient exposes only methods needed for cache
 type slackClient interface {
 	GetConversations() ([]types.Conversation, error)
+	GetConversationInfo(channelId string) (*types.Conversation, error)
+	GetUserGroups() ([]types.UserGroup, error)
 }
 
 type Cache interface {
 	GetChannelID(channelName string, client slackClient) (*types.Conversation, error)
+	// GetConversation finds conversation by id, including ones not in the conversation list such as DMs
+	GetConversation(channelId string, client slackClient) (*types.Conversation, error)
+	// SetConversation stores fresh conversation data, e.g. after channel got renamed
+	SetConversation(conv types.Conversation)
+	GetUserGroup(handle string, client slackClient) (*types.UserGroup, error)
+	GetUserGroupById(userGroupId string, client slackClient) (*types.UserGroup, error)
+	// SetUserGroup stores fresh user group data, disabled user groups are dropped
+	SetUserGroup(group types.UserGroup)
 }
 
 type cache struct {
+	// cache is used by both command handlers and incoming events
+	mu  sync.Mutex
 	cfg *Config
-	// conversationsList maps channel names to other channel data
+	// conversationsList maps channel names to other channel data, only public channels that aren't archived are
+	// listed, the same ones slack returns for the conversation list
 	conversationsList       map[string]types.Conversation
 	conversationListUpdated *time.Time
+	// conversationsByID maps channel ids to channel data
+	conversationsByID map[string]types.Conversation
+	// userGroupsList maps user group handles to other user group data
+	userGroupsList        map[string]types.UserGroup
+	userGroupsListUpdated *time.Time
 }
 
 func (c *cache) isConversationListUpdateNeeded() bool {
-	return c.conversationListUpdated == nil ||
-		time.Since(*c.conversationListUpdated) > c.cfg.RenewConversationListFrequency
+	return isUpdateNeeded(c.conversationListUpdated, c.cfg.RenewConversationListFrequency)
+}
+
+func isUpdateNeeded(updated *time.Time, frequency time.Duration) bool {
+	return updated == nil || time.Since(*updated) > frequency
 }
 
 func (c *cache) updateConversationList(client slackClient) error {
@@ -50,10 +74,36 @@ func (c *cache) updateConversationList(client slackClient) error {
 	for k := range c.conversationsList {
 		delete(c.conversationsList, k)
 	}
+	for k := range c.conversationsByID {
+		delete(c.conversationsByID, k)
+	}
 
 	// add new values
 	for i := range conv {
-		c.conversationsList[conv[i].Name] = conv[i]
+		if isListedByName(conv[i]) {
+			c.conversationsList[conv[i].Name] = conv[i]
+		}
+		c.conversationsByID[conv[i].ID] = conv[i]
+	}
+
+	return nil
+}
+
+func (c *cache) updateUserGroupsList(client slackClient) error {
+	groups, err := client.GetUserGroups()
+	if err != nil {
+		return err
+	}
+
+	n := time.Now()
+	c.userGroupsListUpdated = &n
+
+	for k := range c.userGroupsList {
+		delete(c.userGroupsList, k)
+	}
+
+	for i := range groups {
+		c.userGroupsList[groups[i].Handle] = groups[i]
 	}
 
 	return nil
@@ -62,6 +112,9 @@ func (c *cache) updateConversationList(client slackClient) error {
 // GetChannelID will get channel ID from cache or make relevant API call if
 // cache is empty or time to renew cache has come (defined by config)
 func (c *cache) GetChannelID(channelName string, client slackClient) (*types.Conversation, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	if c.isConversationListUpdateNeeded() {
 		err := c.updateConversationList(client)
 		if err != nil {
@@ -76,9 +129,121 @@ func (c *cache) GetChannelID(channelName string, client slackClient) (*types.Con
 	}
 }
 
+// GetConversation will get conversation from cache, renewing it the same way as GetChannelID.
+// Conversations missing from the list are fetched one by one and kept until the next renewal.
+func (c *cache) GetConversation(channelId string, client slackClient) (*types.Conversation, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.isConversationListUpdateNeeded() {
+		err := c.updateConversationList(client)
+		if err != nil {
+			log.Err(err).Msg("can't update conversation list cache")
+		}
+	}
+
+	if out, ok := c.conversationsByID[channelId]; ok {
+		return &out, nil
+	}
+
+	out, err := client.GetConversationInfo(channelId)
+	if err != nil {
+		return nil, err
+	}
+	c.conversationsByID[channelId] = *out
+	return out, nil
+}
+
+// SetConversation replaces cached conversation, dropping its previous name from the conversation list.
+// Archived and private channels are only kept by id, so they can't be looked up by name.
+func (c *cache) SetConversation(conv types.Conversation) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if old, ok := c.conversationsByID[conv.ID]; ok {
+		if named, ok := c.conversationsList[old.Name]; ok && named.ID == conv.ID {
+			delete(c.conversationsList, old.Name)
+		}
+	}
+
+	c.conversationsByID[conv.ID] = conv
+	if isListedByName(conv) {
+		c.conversationsList[conv.Name] = conv
+	}
+}
+
+// isListedByName tells whether conversation can be looked up by name, i.e. it's a public channel that isn't archived
+func isListedByName(conv types.Conversation) bool {
+	switch {
+	case conv.Name == "" || conv.IsArchived:
+		return false
+	case conv.Type == "group" || conv.Type == "im" || conv.Type == "mpim":
+		return false
+	default:
+		return true
+	}
+}
+
+// GetUserGroup will get user group from cache or make relevant API call if cache is empty
+// or time to renew cache has come (same frequency as conversation list)
+func (c *cache) GetUserGroup(handle string, client slackClient) (*types.UserGroup, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if isUpdateNeeded(c.userGroupsListUpdated, c.cfg.RenewConversationListFrequency) {
+		err := c.updateUserGroupsList(client)
+		if err != nil {
+			log.Err(err).Msg("can't update user groups list cache")
+		}
+	}
+
+	if out, ok := c.userGroupsList[handle]; !ok {
+		return nil, errNoSuchUserGroup
+	} else {
+		return &out, nil
+	}
+}
+
+// GetUserGroupById looks user group up in the same list as GetUserGroup
+func (c *cache) GetUserGroupById(userGroupId string, client slackClient) (*types.UserGroup, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if isUpdateNeeded(c.userGroupsListUpdated, c.cfg.RenewConversationListFrequency) {
+		err := c.updateUserGroupsList(client)
+		if err != nil {
+			log.Err(err).Msg("can't update user groups list cache")
+		}
+	}
+
+	for _, g := range c.userGroupsList {
+		if g.ID == userGroupId {
+			return &g, nil
+		}
+	}
+	return nil, errNoSuchUserGroupId
+}
+
+// SetUserGroup replaces cached user group, dropping its previous handle from the user groups list
+func (c *cache) SetUserGroup(group types.UserGroup) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for handle, g := range c.userGroupsList {
+		if g.ID == group.ID {
+			delete(c.userGroupsList, handle)
+		}
+	}
+	if !group.IsDisabled {
+		c.userGroupsList[group.Handle] = group
+	}
+}
+
 func New(config *Config) Cache {
 	return &cache{
 		cfg:               config,
 		conversationsList: make(map[string]types.Conversation),
+		conversationsByID: make(map[string]types.Conversation),
+		userGroupsList:    make(map[string]types.UserGroup),
 	}
 }
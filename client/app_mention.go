@@ -0,0 +1,59 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"github.com/ExpediaGroup/flyte-client/flyte"
+	"github.com/slack-go/slack"
+	"regexp"
+	"strings"
+)
+
+type appMentionedEvent struct {
+	MessageEvent
+	// CommandText is the message with bot mentions stripped, e.g. "restart foo" for "<@U123> restart foo"
+	CommandText string `json:"commandText"`
+}
+
+// mentionsBot is true for messages from other users that mention the bot user
+func (sl *slackClient) mentionsBot(e *slack.MessageEvent) bool {
+	if sl.botUserId == "" || e.User == sl.botUserId {
+		return false
+	}
+	return strings.Contains(e.Text, "<@"+sl.botUserId+">") || strings.Contains(e.Text, "<@"+sl.botUserId+"|")
+}
+
+func toFlyteAppMentionedEvent(msg MessageEvent, botMention *regexp.Regexp) flyte.Event {
+	return flyte.Event{
+		EventDef: flyte.EventDef{Name: "AppMentioned"},
+		Payload: appMentionedEvent{
+			MessageEvent: msg,
+			CommandText:  stripBotMention(msg.Message, botMention),
+		},
+	}
+}
+
+// newBotMentionRegexp matches bot mentions along with punctuation following them, it's compiled once connected
+func newBotMentionRegexp(botUserId string) *regexp.Regexp {
+	return regexp.MustCompile(`[ \t]*<@` + regexp.QuoteMeta(botUserId) + `(\|[^>]*)?>[:,]?[ \t]*`)
+}
+
+// stripBotMention removes bot mentions matched by newBotMentionRegexp, so that
+// "<@U123>: restart foo" becomes "restart foo"
+func stripBotMention(text string, botMention *regexp.Regexp) string {
+	return strings.TrimSpace(botMention.ReplaceAllString(text, " "))
+}
@@ -0,0 +1,92 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"github.com/ExpediaGroup/flyte-client/flyte"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestMessageMentioningBotEmitsAppMentionedEvent(t *testing.T) {
+	Before(t)
+	SlackMockClient.AddMockGetUserInfoCall("user-id-123", &slack.User{ID: "user-id-123", Name: "jdoe"}, nil)
+	SlackMockClient.AddMockGetUserInfoCall("UBOT", &slack.User{ID: "UBOT", Name: "flyte"}, nil)
+
+	events := SlackImpl.(*slackClient).incomingEvents
+	events <- slack.RTMEvent{Type: "connected", Data: &slack.ConnectedEvent{Info: &slack.Info{User: &slack.UserDetails{ID: "UBOT"}}}}
+	events <- slack.RTMEvent{Type: "message", Data: &slack.MessageEvent{
+		Msg: slack.Msg{Channel: "C123", User: "user-id-123", Text: "<@UBOT>: restart foo", Timestamp: "2.0", ThreadTimestamp: "1.0"},
+	}}
+
+	received := nextEvent(t)
+	assert.Equal(t, "ReceivedMessage", received.EventDef.Name)
+
+	mentioned := nextEvent(t)
+	require.Equal(t, "AppMentioned", mentioned.EventDef.Name)
+	payload := mentioned.Payload.(appMentionedEvent)
+	assert.Equal(t, "restart foo", payload.CommandText)
+	assert.Equal(t, "<@UBOT>: restart foo", payload.Message)
+	assert.Equal(t, "jdoe", payload.User.Name)
+	assert.Equal(t, "C123", payload.ChannelId)
+	assert.Equal(t, "1.0", payload.ThreadTimestamp)
+}
+
+func TestMessageWithoutBotMentionEmitsOnlyReceivedMessage(t *testing.T) {
+	Before(t)
+	SlackMockClient.AddMockGetUserInfoCall("user-id-123", &slack.User{ID: "user-id-123"}, nil)
+
+	events := SlackImpl.(*slackClient).incomingEvents
+	events <- slack.RTMEvent{Type: "connected", Data: &slack.ConnectedEvent{Info: &slack.Info{User: &slack.UserDetails{ID: "UBOT"}}}}
+	events <- slack.RTMEvent{Type: "message", Data: &slack.MessageEvent{
+		Msg: slack.Msg{Channel: "C123", User: "user-id-123", Text: "restart foo", Timestamp: "1.0"},
+	}}
+
+	assert.Equal(t, "ReceivedMessage", nextEvent(t).EventDef.Name)
+	select {
+	case e := <-SlackImpl.IncomingMessages():
+		assert.Fail(t, "unexpected event", e.EventDef.Name)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestStripBotMention(t *testing.T) {
+	cases := map[string]string{
+		"<@UBOT> restart foo":           "restart foo",
+		"<@UBOT|flyte>, restart foo":    "restart foo",
+		"please <@UBOT> restart foo":    "please restart foo",
+		"<@UBOT>\nrestart\nfoo":         "restart\nfoo",
+		"<@UOTHER> <@UBOT> restart foo": "<@UOTHER> restart foo",
+	}
+	botMention := newBotMentionRegexp("UBOT")
+	for in, want := range cases {
+		assert.Equal(t, want, stripBotMention(in, botMention), in)
+	}
+}
+
+func nextEvent(t *testing.T) flyte.Event {
+	select {
+	case e := <-SlackImpl.IncomingMessages():
+		return e
+	case <-time.After(250 * time.Millisecond):
+		require.Fail(t, "expected event")
+		return flyte.Event{}
+	}
+}
@@ -0,0 +1,79 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+	"github.com/ExpediaGroup/flyte-slack/types"
+	"github.com/rs/zerolog/log"
+	"path"
+)
+
+func (sl *slackClient) JoinChannel(channelId string) (*types.Conversation, error) {
+	ch, _, _, err := sl.client.JoinConversation(channelId)
+	if err != nil {
+		return nil, fmt.Errorf("cannot join channel=%s: %v", channelId, err)
+	}
+
+	conv := toConversation(ch)
+	sl.cache.SetConversation(conv)
+	log.Info().Msgf("joined channel=%s", channelId)
+	return &conv, nil
+}
+
+// LeaveChannel succeeds when the bot isn't in the channel already
+func (sl *slackClient) LeaveChannel(channelId string) error {
+	notInChannel, err := sl.client.LeaveConversation(channelId)
+	if err != nil {
+		return fmt.Errorf("cannot leave channel=%s: %v", channelId, err)
+	}
+
+	if notInChannel {
+		log.Info().Msgf("not in channel=%s, nothing to leave", channelId)
+		return nil
+	}
+	log.Info().Msgf("left channel=%s", channelId)
+	return nil
+}
+
+// autoJoin joins public channels with names matching any of the patterns, see path.Match for pattern syntax.
+// Failures are only logged, the bot keeps running without those channels.
+func (sl *slackClient) autoJoin(patterns []string) {
+	convs, err := sl.GetConversations()
+	if err != nil {
+		log.Err(err).Msgf("cannot list channels to auto join %v", patterns)
+		return
+	}
+
+	for _, c := range convs {
+		if c.Type != "channel" || !matchesAny(c.Name, patterns) {
+			continue
+		}
+		if _, err := sl.JoinChannel(c.ID); err != nil {
+			log.Err(err).Msgf("cannot auto join channel=%s", c.Name)
+		}
+	}
+}
+
+func matchesAny(name string, patterns []string) bool {
+	for _, p := range patterns {
+		if ok, _ := path.Match(p, name); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"errors"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestAutoJoinJoinsMatchingPublicChannels(t *testing.T) {
+	Before(t)
+	SlackMockClient.GetConversationsFunc = func(params *slack.GetConversationsParameters) ([]slack.Channel, string, error) {
+		var chans []slack.Channel
+		for _, c := range []struct {
+			id, name  string
+			isPrivate bool
+		}{{"C1", "sre", false}, {"C2", "inc-payments", false}, {"C3", "inc-secret", true}, {"C4", "random", false}} {
+			ch := slack.Channel{}
+			ch.ID = c.id
+			ch.Name = c.name
+			ch.IsPrivate = c.isPrivate
+			chans = append(chans, ch)
+		}
+		return chans, "", nil
+	}
+	var joined []string
+	SlackMockClient.JoinConversationFunc = func(channelID string) (*slack.Channel, string, []string, error) {
+		joined = append(joined, channelID)
+		if channelID == "C1" {
+			return nil, "", nil, errors.New("is_archived")
+		}
+		ch := &slack.Channel{}
+		ch.ID = channelID
+		return ch, "", nil, nil
+	}
+
+	SlackImpl.(*slackClient).autoJoin([]string{"sre", "inc-*"})
+
+	assert.Equal(t, []string{"C1", "C2"}, joined)
+}
+
+func TestLeaveChannelSucceedsWhenNotInChannel(t *testing.T) {
+	Before(t)
+	SlackMockClient.LeaveConversationFunc = func(channelID string) (bool, error) {
+		return true, nil
+	}
+
+	require.NoError(t, SlackImpl.LeaveChannel("C123"))
+}
+
+func TestLeaveChannelFails(t *testing.T) {
+	Before(t)
+	SlackMockClient.LeaveConversationFunc = func(channelID string) (bool, error) {
+		return false, errors.New("cant_leave_general")
+	}
+
+	err := SlackImpl.LeaveChannel("C123")
+
+	require.Error(t, err)
+	assert.Equal(t, "cannot leave channel=C123: cant_leave_general", err.Error())
+}
@@ -0,0 +1,81 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"github.com/ExpediaGroup/flyte-client/flyte"
+	"github.com/ExpediaGroup/flyte-slack/types"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	channelCreatedEventName    = "ChannelCreated"
+	channelRenamedEventName    = "ChannelRenamed"
+	channelArchivedEventName   = "ChannelArchived"
+	channelUnarchivedEventName = "ChannelUnarchived"
+)
+
+type channelLifecycleEvent struct {
+	Channel types.Conversation `json:"channel"`
+	// User is the acting user, slack doesn't send it for renames
+	User *User `json:"user"`
+	// PreviousName is only set for renamed channels known to the cache
+	PreviousName string `json:"previousName,omitempty"`
+}
+
+// channelLifecycleEvent refreshes cached channel and builds event for it. Channel name from the slack event
+// is used when fresh channel details can't be fetched.
+func (sl *slackClient) channelLifecycleEvent(name, channelId, channelName, userId string) flyte.Event {
+	e := channelLifecycleEvent{User: sl.actingUser(userId)}
+	if name == channelRenamedEventName {
+		e.PreviousName = sl.conversation(channelId).Name
+	}
+
+	conv, err := sl.GetConversationInfo(channelId)
+	if err != nil {
+		log.Err(err).Msgf("cannot get info about channel=%s", channelId)
+		c := sl.conversation(channelId)
+		if channelName != "" {
+			c.Name = channelName
+		}
+		conv = &c
+	}
+	sl.cache.SetConversation(*conv)
+
+	e.Channel = *conv
+	if e.PreviousName == conv.Name {
+		e.PreviousName = ""
+	}
+
+	return flyte.Event{
+		EventDef: flyte.EventDef{Name: name},
+		Payload:  e,
+	}
+}
+
+func (sl *slackClient) actingUser(userId string) *User {
+	if userId == "" {
+		return nil
+	}
+	u, err := sl.userInfo(userId)
+	if err != nil {
+		log.Err(err).Msgf("cannot get info about user=%s", userId)
+		return &User{Id: userId}
+	}
+	out := newUser(u)
+	return &out
+}
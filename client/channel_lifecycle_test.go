@@ -0,0 +1,107 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"errors"
+	"github.com/ExpediaGroup/flyte-slack/types"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestChannelCreatedEvent(t *testing.T) {
+	Before(t)
+	SlackMockClient.AddMockGetUserInfoCall("U1", &slack.User{ID: "U1", Name: "jdoe"}, nil)
+	SlackMockClient.GetConversationInfoFunc = func(channelID string, includeLocale bool) (*slack.Channel, error) {
+		ch := &slack.Channel{}
+		ch.ID = channelID
+		ch.Name = "team-x"
+		ch.Topic.Value = "all things x"
+		return ch, nil
+	}
+
+	SlackImpl.(*slackClient).incomingEvents <- slack.RTMEvent{Type: "channel_created", Data: &slack.ChannelCreatedEvent{
+		Channel: slack.ChannelCreatedInfo{ID: "C123", Name: "team-x", Creator: "U1"},
+	}}
+
+	e := nextEvent(t)
+	require.Equal(t, "ChannelCreated", e.EventDef.Name)
+	payload := e.Payload.(channelLifecycleEvent)
+	assert.Equal(t, types.Conversation{ID: "C123", Name: "team-x", Topic: "all things x", Type: "channel"}, payload.Channel)
+	require.NotNil(t, payload.User)
+	assert.Equal(t, "jdoe", payload.User.Name)
+}
+
+func TestChannelRenamedEventUpdatesCache(t *testing.T) {
+	Before(t)
+	names := []string{"old-name", "new-name"}
+	SlackMockClient.GetConversationInfoFunc = func(channelID string, includeLocale bool) (*slack.Channel, error) {
+		ch := &slack.Channel{}
+		ch.ID = channelID
+		ch.Name, names = names[0], names[1:]
+		return ch, nil
+	}
+
+	SlackImpl.(*slackClient).incomingEvents <- slack.RTMEvent{Type: "channel_rename", Data: &slack.ChannelRenameEvent{
+		Channel: slack.ChannelRenameInfo{ID: "C123", Name: "new-name"},
+	}}
+
+	e := nextEvent(t)
+	require.Equal(t, "ChannelRenamed", e.EventDef.Name)
+	payload := e.Payload.(channelLifecycleEvent)
+	assert.Equal(t, "new-name", payload.Channel.Name)
+	assert.Equal(t, "old-name", payload.PreviousName)
+	assert.Nil(t, payload.User)
+
+	c, err := SlackImpl.(*slackClient).cache.GetChannelID("new-name", SlackImpl)
+	require.NoError(t, err)
+	assert.Equal(t, "C123", c.ID)
+	_, err = SlackImpl.(*slackClient).cache.GetChannelID("old-name", SlackImpl)
+	assert.Error(t, err)
+}
+
+func TestChannelArchivedAndUnarchivedEvents(t *testing.T) {
+	tests := []struct {
+		event slack.RTMEvent
+		name  string
+	}{
+		{event: slack.RTMEvent{Type: "channel_archive", Data: &slack.ChannelArchiveEvent{Channel: "C123", User: "U1"}}, name: "ChannelArchived"},
+		{event: slack.RTMEvent{Type: "group_archive", Data: &slack.GroupArchiveEvent{Channel: "C123", User: "U1"}}, name: "ChannelArchived"},
+		{event: slack.RTMEvent{Type: "channel_unarchive", Data: &slack.ChannelUnarchiveEvent{Channel: "C123", User: "U1"}}, name: "ChannelUnarchived"},
+		{event: slack.RTMEvent{Type: "group_unarchive", Data: &slack.GroupUnarchiveEvent{Channel: "C123", User: "U1"}}, name: "ChannelUnarchived"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.event.Type, func(t *testing.T) {
+			Before(t)
+			SlackMockClient.AddMockGetUserInfoCall("U1", &slack.User{ID: "U1", Name: "jdoe"}, nil)
+			SlackMockClient.GetConversationInfoFunc = func(channelID string, includeLocale bool) (*slack.Channel, error) {
+				return nil, errors.New("channel_not_found")
+			}
+
+			SlackImpl.(*slackClient).incomingEvents <- test.event
+
+			e := nextEvent(t)
+			require.Equal(t, test.name, e.EventDef.Name)
+			payload := e.Payload.(channelLifecycleEvent)
+			assert.Equal(t, "C123", payload.Channel.ID)
+			assert.Equal(t, "jdoe", payload.User.Name)
+		})
+	}
+}
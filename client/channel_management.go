@@ -0,0 +1,119 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+	"github.com/ExpediaGroup/flyte-slack/types"
+	"github.com/rs/zerolog/log"
+)
+
+// CreateChannel creates channel and invites members to it. Members that can't be invited are reported the same way
+// as by InviteToChannel, error is only returned when the channel couldn't be created.
+func (sl *slackClient) CreateChannel(name string, isPrivate bool, memberIds []string) (*types.Conversation, []string, []UserFailure, error) {
+	ch, err := sl.client.CreateConversation(name, isPrivate)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot create channel=%s: %v", name, err)
+	}
+
+	conv := toConversation(ch)
+	sl.cache.SetConversation(conv)
+	log.Info().Msgf("channel=%s created id=%s", name, conv.ID)
+
+	if len(memberIds) == 0 {
+		return &conv, []string{}, []UserFailure{}, nil
+	}
+	invited, failed, err := sl.InviteToChannel(conv.ID, memberIds)
+	if err != nil {
+		// members the invite didn't get to fail with the channel error
+		handled := map[string]bool{}
+		for _, id := range invited {
+			handled[id] = true
+		}
+		for _, f := range failed {
+			handled[f.User] = true
+		}
+		for _, id := range memberIds {
+			if !handled[id] {
+				failed = append(failed, UserFailure{User: id, Reason: err.Error()})
+			}
+		}
+	}
+	return &conv, invited, failed, nil
+}
+
+func (sl *slackClient) ArchiveChannel(channelId string) error {
+	if err := sl.client.ArchiveConversation(channelId); err != nil {
+		return fmt.Errorf("cannot archive channel=%s: %v", channelId, err)
+	}
+
+	sl.setArchived(channelId, true)
+	log.Info().Msgf("channel=%s archived", channelId)
+	return nil
+}
+
+func (sl *slackClient) UnarchiveChannel(channelId string) error {
+	if err := sl.client.UnArchiveConversation(channelId); err != nil {
+		return fmt.Errorf("cannot unarchive channel=%s: %v", channelId, err)
+	}
+
+	sl.setArchived(channelId, false)
+	log.Info().Msgf("channel=%s unarchived", channelId)
+	return nil
+}
+
+func (sl *slackClient) setArchived(channelId string, archived bool) {
+	conv := sl.conversation(channelId)
+	conv.IsArchived = archived
+	sl.cache.SetConversation(conv)
+}
+
+func (sl *slackClient) RenameChannel(channelId, name string) (*types.Conversation, error) {
+	ch, err := sl.client.RenameConversation(channelId, name)
+	if err != nil {
+		return nil, fmt.Errorf("cannot rename channel=%s to %s: %v", channelId, name, err)
+	}
+
+	conv := toConversation(ch)
+	sl.cache.SetConversation(conv)
+	log.Info().Msgf("channel=%s renamed to %s", channelId, name)
+	return &conv, nil
+}
+
+func (sl *slackClient) SetChannelTopic(channelId, topic string) (*types.Conversation, error) {
+	ch, err := sl.client.SetTopicOfConversation(channelId, topic)
+	if err != nil {
+		return nil, fmt.Errorf("cannot set topic of channel=%s: %v", channelId, err)
+	}
+
+	conv := toConversation(ch)
+	sl.cache.SetConversation(conv)
+	log.Info().Msgf("channel=%s topic set to %q", channelId, topic)
+	return &conv, nil
+}
+
+func (sl *slackClient) SetChannelPurpose(channelId, purpose string) (*types.Conversation, error) {
+	ch, err := sl.client.SetPurposeOfConversation(channelId, purpose)
+	if err != nil {
+		return nil, fmt.Errorf("cannot set purpose of channel=%s: %v", channelId, err)
+	}
+
+	conv := toConversation(ch)
+	sl.cache.SetConversation(conv)
+	log.Info().Msgf("channel=%s purpose set to %q", channelId, purpose)
+	return &conv, nil
+}
@@ -0,0 +1,204 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"errors"
+	"github.com/ExpediaGroup/flyte-slack/types"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCreateChannelUpdatesCacheAndInvitesMembers(t *testing.T) {
+	Before(t)
+	SlackMockClient.CreateConversationFunc = func(channelName string, isPrivate bool) (*slack.Channel, error) {
+		ch := &slack.Channel{}
+		ch.ID = "C123"
+		ch.Name = channelName
+		ch.IsPrivate = isPrivate
+		return ch, nil
+	}
+	var invited []string
+	SlackMockClient.InviteUsersFunc = func(channelID string, users ...string) (*slack.Channel, error) {
+		invited = users
+		return nil, nil
+	}
+
+	// conversation list loaded before the channel existed
+	_, err := SlackImpl.(*slackClient).cache.GetChannelID("inc-payments", SlackImpl)
+	require.Error(t, err)
+
+	conv, done, failed, err := SlackImpl.CreateChannel("inc-payments", true, []string{"U1", "U2"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "group", conv.Type)
+	assert.Equal(t, []string{"U1", "U2"}, invited)
+	assert.Equal(t, []string{"U1", "U2"}, done)
+	assert.Empty(t, failed)
+	cached, err := SlackImpl.(*slackClient).cache.GetConversation("C123", SlackImpl)
+	require.NoError(t, err)
+	assert.Equal(t, "inc-payments", cached.Name)
+	// private channels can't be looked up by name
+	_, err = SlackImpl.(*slackClient).cache.GetChannelID("inc-payments", SlackImpl)
+	assert.Error(t, err)
+}
+
+func TestCreatePublicChannelCanBeLookedUpByName(t *testing.T) {
+	Before(t)
+	SlackMockClient.CreateConversationFunc = func(channelName string, isPrivate bool) (*slack.Channel, error) {
+		ch := &slack.Channel{}
+		ch.ID = "C123"
+		ch.Name = channelName
+		return ch, nil
+	}
+	_, err := SlackImpl.(*slackClient).cache.GetChannelID("inc-payments", SlackImpl)
+	require.Error(t, err)
+
+	_, _, _, err = SlackImpl.CreateChannel("inc-payments", false, nil)
+
+	require.NoError(t, err)
+	cached, err := SlackImpl.(*slackClient).cache.GetChannelID("inc-payments", SlackImpl)
+	require.NoError(t, err)
+	assert.Equal(t, "C123", cached.ID)
+}
+
+func TestCreateChannelReportsMembersThatCannotBeInvited(t *testing.T) {
+	Before(t)
+	SlackMockClient.CreateConversationFunc = func(channelName string, isPrivate bool) (*slack.Channel, error) {
+		ch := &slack.Channel{}
+		ch.ID = "C123"
+		return ch, nil
+	}
+	SlackMockClient.InviteUsersFunc = func(channelID string, users ...string) (*slack.Channel, error) {
+		for _, u := range users {
+			if u == "U404" {
+				return nil, errors.New("user_not_found")
+			}
+		}
+		return nil, nil
+	}
+
+	conv, invited, failed, err := SlackImpl.CreateChannel("inc-payments", false, []string{"U1", "U404"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "C123", conv.ID)
+	assert.Equal(t, []string{"U1"}, invited)
+	assert.Equal(t, []UserFailure{{User: "U404", Reason: "user_not_found"}}, failed)
+}
+
+func TestArchiveChannelUpdatesCache(t *testing.T) {
+	Before(t)
+	SlackMockClient.ArchiveConversationFunc = func(channelID string) error { return nil }
+	SlackMockClient.UnArchiveConversationFunc = func(channelID string) error { return nil }
+	_, err := SlackImpl.(*slackClient).cache.GetChannelID("inc-payments", SlackImpl)
+	require.Error(t, err)
+	SlackImpl.(*slackClient).cache.SetConversation(types.Conversation{ID: "C123", Name: "inc-payments", Type: "channel"})
+
+	require.NoError(t, SlackImpl.ArchiveChannel("C123"))
+	conv, err := SlackImpl.(*slackClient).cache.GetConversation("C123", SlackImpl)
+	require.NoError(t, err)
+	assert.True(t, conv.IsArchived)
+	// archived channels can't be looked up by name
+	_, err = SlackImpl.(*slackClient).cache.GetChannelID("inc-payments", SlackImpl)
+	assert.Error(t, err)
+
+	require.NoError(t, SlackImpl.UnarchiveChannel("C123"))
+	conv, err = SlackImpl.(*slackClient).cache.GetConversation("C123", SlackImpl)
+	require.NoError(t, err)
+	assert.False(t, conv.IsArchived)
+	_, err = SlackImpl.(*slackClient).cache.GetChannelID("inc-payments", SlackImpl)
+	assert.NoError(t, err)
+}
+
+func TestRenameChannelUpdatesCache(t *testing.T) {
+	Before(t)
+	SlackMockClient.RenameConversationFunc = func(channelID, channelName string) (*slack.Channel, error) {
+		ch := &slack.Channel{}
+		ch.ID = channelID
+		ch.Name = channelName
+		return ch, nil
+	}
+
+	_, err := SlackImpl.(*slackClient).cache.GetChannelID("inc-payments", SlackImpl)
+	require.Error(t, err)
+
+	conv, err := SlackImpl.RenameChannel("C123", "inc-payments-resolved")
+
+	require.NoError(t, err)
+	assert.Equal(t, "inc-payments-resolved", conv.Name)
+	cached, err := SlackImpl.(*slackClient).cache.GetChannelID("inc-payments-resolved", SlackImpl)
+	require.NoError(t, err)
+	assert.Equal(t, "C123", cached.ID)
+}
+
+func TestSetChannelTopicUpdatesCache(t *testing.T) {
+	Before(t)
+	SlackMockClient.SetTopicFunc = func(channelID, topic string) (*slack.Channel, error) {
+		ch := &slack.Channel{}
+		ch.ID = channelID
+		ch.Name = "sre"
+		ch.Topic.Value = topic
+		return ch, nil
+	}
+
+	_, err := SlackImpl.(*slackClient).cache.GetChannelID("sre", SlackImpl)
+	require.Error(t, err)
+
+	conv, err := SlackImpl.SetChannelTopic("C123", "On call: <@U1>")
+
+	require.NoError(t, err)
+	assert.Equal(t, "On call: <@U1>", conv.Topic)
+	cached, err := SlackImpl.(*slackClient).cache.GetConversation("C123", SlackImpl)
+	require.NoError(t, err)
+	assert.Equal(t, "On call: <@U1>", cached.Topic)
+}
+
+func TestSetChannelPurposeUpdatesCache(t *testing.T) {
+	Before(t)
+	SlackMockClient.SetPurposeFunc = func(channelID, purpose string) (*slack.Channel, error) {
+		ch := &slack.Channel{}
+		ch.ID = channelID
+		ch.Name = "sre"
+		ch.Purpose.Value = purpose
+		return ch, nil
+	}
+	// conversation list loaded before the purpose was set
+	_, err := SlackImpl.(*slackClient).cache.GetChannelID("sre", SlackImpl)
+	require.Error(t, err)
+
+	conv, err := SlackImpl.SetChannelPurpose("C123", "Site reliability")
+
+	require.NoError(t, err)
+	assert.Equal(t, "Site reliability", conv.Purpose)
+	cached, err := SlackImpl.(*slackClient).cache.GetConversation("C123", SlackImpl)
+	require.NoError(t, err)
+	assert.Equal(t, "Site reliability", cached.Purpose)
+}
+
+func TestSetChannelPurposeFails(t *testing.T) {
+	Before(t)
+	SlackMockClient.SetPurposeFunc = func(channelID, purpose string) (*slack.Channel, error) {
+		return nil, errors.New("not_in_channel")
+	}
+
+	_, err := SlackImpl.SetChannelPurpose("C123", "SRE team chat")
+
+	require.Error(t, err)
+	assert.Equal(t, "cannot set purpose of channel=C123: not_in_channel", err.Error())
+}
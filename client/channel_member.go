@@ -0,0 +1,74 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"github.com/ExpediaGroup/flyte-client/flyte"
+	"github.com/ExpediaGroup/flyte-slack/types"
+	"github.com/rs/zerolog/log"
+	"github.com/slack-go/slack"
+)
+
+type channelMemberEvent struct {
+	User        User   `json:"user"`
+	ChannelId   string `json:"channelId"`
+	ChannelName string `json:"channelName"`
+	ChannelType string `json:"channelType"`
+	// Inviter is only known for users added by someone else
+	Inviter *User `json:"inviter"`
+}
+
+func newChannelMemberEvent(u *slack.User, inviter *slack.User, conv types.Conversation) channelMemberEvent {
+	e := channelMemberEvent{
+		User:        newUser(u),
+		ChannelId:   conv.ID,
+		ChannelName: conv.Name,
+		ChannelType: conv.Type,
+	}
+	if inviter != nil {
+		i := newUser(inviter)
+		e.Inviter = &i
+	}
+	return e
+}
+
+// inviter resolves user that added member to channel, nil when member joined on their own or user can't be found
+func (sl *slackClient) inviter(userId string) *slack.User {
+	if userId == "" {
+		return nil
+	}
+	u, err := sl.userInfo(userId)
+	if err != nil {
+		log.Err(err).Msgf("cannot get info about inviter=%s", userId)
+		return nil
+	}
+	return u
+}
+
+func toFlyteMemberJoinedChannelEvent(u *slack.User, inviter *slack.User, conv types.Conversation) flyte.Event {
+	return flyte.Event{
+		EventDef: flyte.EventDef{Name: "MemberJoinedChannel"},
+		Payload:  newChannelMemberEvent(u, inviter, conv),
+	}
+}
+
+func toFlyteMemberLeftChannelEvent(u *slack.User, conv types.Conversation) flyte.Event {
+	return flyte.Event{
+		EventDef: flyte.EventDef{Name: "MemberLeftChannel"},
+		Payload:  newChannelMemberEvent(u, nil, conv),
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestMemberJoinedChannelEvent(t *testing.T) {
+	Before(t)
+	SlackMockClient.AddMockGetUserInfoCall("U1", &slack.User{ID: "U1", Name: "jdoe"}, nil)
+	SlackMockClient.AddMockGetUserInfoCall("U2", &slack.User{ID: "U2", Name: "admin"}, nil)
+	SlackMockClient.GetConversationInfoFunc = func(channelID string, includeLocale bool) (*slack.Channel, error) {
+		ch := &slack.Channel{}
+		ch.ID = channelID
+		ch.Name = "team-x"
+		return ch, nil
+	}
+
+	SlackImpl.(*slackClient).incomingEvents <- slack.RTMEvent{Type: "member_joined_channel", Data: &slack.MemberJoinedChannelEvent{
+		User: "U1", Channel: "C123", ChannelType: "C", Inviter: "U2",
+	}}
+
+	e := nextEvent(t)
+	require.Equal(t, "MemberJoinedChannel", e.EventDef.Name)
+	payload := e.Payload.(channelMemberEvent)
+	assert.Equal(t, "jdoe", payload.User.Name)
+	assert.Equal(t, "C123", payload.ChannelId)
+	assert.Equal(t, "team-x", payload.ChannelName)
+	assert.Equal(t, "channel", payload.ChannelType)
+	require.NotNil(t, payload.Inviter)
+	assert.Equal(t, "admin", payload.Inviter.Name)
+}
+
+func TestMemberLeftChannelEvent(t *testing.T) {
+	Before(t)
+	SlackMockClient.AddMockGetUserInfoCall("U1", &slack.User{ID: "U1", Name: "jdoe"}, nil)
+
+	SlackImpl.(*slackClient).incomingEvents <- slack.RTMEvent{Type: "member_left_channel", Data: &slack.MemberLeftChannelEvent{
+		User: "U1", Channel: "C123", ChannelType: "C",
+	}}
+
+	e := nextEvent(t)
+	require.Equal(t, "MemberLeftChannel", e.EventDef.Name)
+	payload := e.Payload.(channelMemberEvent)
+	assert.Equal(t, "jdoe", payload.User.Name)
+	assert.Equal(t, "C123", payload.ChannelId)
+	assert.Nil(t, payload.Inviter)
+}
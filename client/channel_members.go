@@ -0,0 +1,130 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"github.com/slack-go/slack"
+)
+
+const (
+	inviteBatchSize     = 1000 // max 1000
+	channelMembersLimit = 1000 // page size, max 1000
+)
+
+// channel errors fail the whole invite or removal instead of being reported per user
+var (
+	inviteChannelErrors = map[string]bool{"channel_not_found": true, "not_in_channel": true, "is_archived": true}
+	kickChannelErrors   = map[string]bool{"channel_not_found": true, "is_archived": true, "restricted_action": true}
+)
+
+// inviteUserErrors are caused by some of the invited users, a batch failing with one of them is retried user by user
+var inviteUserErrors = map[string]bool{
+	"already_in_channel":       true,
+	"cant_invite":              true,
+	"cant_invite_self":         true,
+	"user_not_found":           true,
+	"user_is_restricted":       true,
+	"user_is_ultra_restricted": true,
+	"ura_max_channels":         true,
+}
+
+// UserFailure is user that couldn't be added to or removed from channel
+type UserFailure struct {
+	User   string `json:"user"`
+	Reason string `json:"reason"`
+}
+
+// InviteToChannel invites users in batches. A batch failing because of some of its users is retried user by user to find
+// out which users failed, other errors fail all users of the batch.
+func (sl *slackClient) InviteToChannel(channelId string, userIds []string) ([]string, []UserFailure, error) {
+	invited := []string{}
+	failed := []UserFailure{}
+
+	for start := 0; start < len(userIds); start += inviteBatchSize {
+		batch := userIds[start:minInt(start+inviteBatchSize, len(userIds))]
+
+		_, err := sl.client.InviteUsersToConversation(channelId, batch...)
+		if err == nil {
+			invited = append(invited, batch...)
+			continue
+		}
+		if inviteChannelErrors[err.Error()] {
+			return invited, failed, fmt.Errorf("cannot invite users to channel=%s: %v", channelId, err)
+		}
+		if !inviteUserErrors[err.Error()] {
+			for _, id := range batch {
+				failed = append(failed, UserFailure{User: id, Reason: err.Error()})
+			}
+			continue
+		}
+
+		for _, id := range batch {
+			if _, err := sl.client.InviteUsersToConversation(channelId, id); err != nil {
+				failed = append(failed, UserFailure{User: id, Reason: err.Error()})
+				continue
+			}
+			invited = append(invited, id)
+		}
+	}
+
+	log.Info().Msgf("users=%v invited to channel=%s, failed=%v", invited, channelId, failed)
+	return invited, failed, nil
+}
+
+func (sl *slackClient) RemoveFromChannel(channelId string, userIds []string) ([]string, []UserFailure, error) {
+	removed := []string{}
+	failed := []UserFailure{}
+
+	for _, id := range userIds {
+		err := sl.client.KickUserFromConversation(channelId, id)
+		if err == nil {
+			removed = append(removed, id)
+			continue
+		}
+		if kickChannelErrors[err.Error()] {
+			return removed, failed, fmt.Errorf("cannot remove users from channel=%s: %v", channelId, err)
+		}
+		failed = append(failed, UserFailure{User: id, Reason: err.Error()})
+	}
+
+	log.Info().Msgf("users=%v removed from channel=%s, failed=%v", removed, channelId, failed)
+	return removed, failed, nil
+}
+
+// ListChannelMembers pages through all members of the channel
+func (sl *slackClient) ListChannelMembers(channelId string) ([]string, error) {
+	params := &slack.GetUsersInConversationParameters{
+		ChannelID: channelId,
+		Limit:     channelMembersLimit,
+	}
+
+	members := []string{}
+	for {
+		ids, cursor, err := sl.client.GetUsersInConversation(params)
+		if err != nil {
+			return nil, fmt.Errorf("cannot list members of channel=%s: %v", channelId, err)
+		}
+		members = append(members, ids...)
+
+		if cursor == "" {
+			return members, nil
+		}
+		params.Cursor = cursor
+	}
+}
@@ -0,0 +1,129 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestInviteToChannelRetriesFailedBatchUserByUser(t *testing.T) {
+	Before(t)
+	var calls [][]string
+	SlackMockClient.InviteUsersFunc = func(channelID string, users ...string) (*slack.Channel, error) {
+		calls = append(calls, users)
+		for _, u := range users {
+			if u == "U2" {
+				return nil, errors.New("already_in_channel")
+			}
+		}
+		return nil, nil
+	}
+
+	invited, failed, err := SlackImpl.InviteToChannel("C123", []string{"U1", "U2", "U3"})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"U1", "U3"}, invited)
+	assert.Equal(t, []UserFailure{{User: "U2", Reason: "already_in_channel"}}, failed)
+	assert.Equal(t, [][]string{{"U1", "U2", "U3"}, {"U1"}, {"U2"}, {"U3"}}, calls)
+}
+
+func TestInviteToChannelFailsBatchWithoutRetryOnOtherErrors(t *testing.T) {
+	Before(t)
+	calls := 0
+	SlackMockClient.InviteUsersFunc = func(channelID string, users ...string) (*slack.Channel, error) {
+		calls++
+		return nil, errors.New("ratelimited")
+	}
+
+	invited, failed, err := SlackImpl.InviteToChannel("C123", []string{"U1", "U2"})
+
+	require.NoError(t, err)
+	assert.Empty(t, invited)
+	assert.Equal(t, []UserFailure{{User: "U1", Reason: "ratelimited"}, {User: "U2", Reason: "ratelimited"}}, failed)
+	assert.Equal(t, 1, calls)
+}
+
+func TestInviteToChannelFailsOnChannelError(t *testing.T) {
+	Before(t)
+	SlackMockClient.InviteUsersFunc = func(channelID string, users ...string) (*slack.Channel, error) {
+		return nil, errors.New("channel_not_found")
+	}
+
+	_, _, err := SlackImpl.InviteToChannel("C123", []string{"U1", "U2"})
+
+	assert.EqualError(t, err, "cannot invite users to channel=C123: channel_not_found")
+}
+
+func TestInviteToChannelReturnsUsersInvitedBeforeChannelError(t *testing.T) {
+	Before(t)
+	batches := 0
+	SlackMockClient.InviteUsersFunc = func(channelID string, users ...string) (*slack.Channel, error) {
+		batches++
+		if batches > 1 {
+			return nil, errors.New("is_archived")
+		}
+		return nil, nil
+	}
+	userIds := make([]string, inviteBatchSize+1)
+	for i := range userIds {
+		userIds[i] = fmt.Sprintf("U%d", i)
+	}
+
+	invited, failed, err := SlackImpl.InviteToChannel("C123", userIds)
+
+	assert.EqualError(t, err, "cannot invite users to channel=C123: is_archived")
+	assert.Equal(t, userIds[:inviteBatchSize], invited)
+	assert.Empty(t, failed)
+}
+
+func TestRemoveFromChannelReportsPerUserFailures(t *testing.T) {
+	Before(t)
+	SlackMockClient.KickUserFunc = func(channelID string, user string) error {
+		if user == "U2" {
+			return errors.New("not_in_channel")
+		}
+		return nil
+	}
+
+	removed, failed, err := SlackImpl.RemoveFromChannel("C123", []string{"U1", "U2"})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"U1"}, removed)
+	assert.Equal(t, []UserFailure{{User: "U2", Reason: "not_in_channel"}}, failed)
+}
+
+func TestListChannelMembersPagesThroughMembers(t *testing.T) {
+	Before(t)
+	pages := map[string][]string{"": {"U1", "U2"}, "next": {"U3"}}
+	SlackMockClient.GetUsersInConversationFunc = func(params *slack.GetUsersInConversationParameters) ([]string, string, error) {
+		ids := pages[params.Cursor]
+		if params.Cursor == "" {
+			return ids, "next", nil
+		}
+		return ids, "", nil
+	}
+
+	members, err := SlackImpl.ListChannelMembers("C123")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"U1", "U2", "U3"}, members)
+}
@@ -0,0 +1,45 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"github.com/ExpediaGroup/flyte-client/flyte"
+)
+
+type directMessageEvent struct {
+	MessageEvent
+	// IsMultiParty is set for group DMs (mpim) as opposed to one to one DMs (im)
+	IsMultiParty bool `json:"isMultiParty"`
+}
+
+// isDirectMessage is true for messages from other users sent in a DM the bot is part of
+func (sl *slackClient) isDirectMessage(msg MessageEvent) bool {
+	if msg.User.Id == sl.botUserId {
+		return false
+	}
+	return msg.ChannelType == "im" || msg.ChannelType == "mpim"
+}
+
+func toFlyteDirectMessageEvent(msg MessageEvent) flyte.Event {
+	return flyte.Event{
+		EventDef: flyte.EventDef{Name: "DirectMessageReceived"},
+		Payload: directMessageEvent{
+			MessageEvent: msg,
+			IsMultiParty: msg.ChannelType == "mpim",
+		},
+	}
+}
@@ -0,0 +1,60 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestDirectMessageEmitsDirectMessageReceivedEvent(t *testing.T) {
+	cases := map[string]struct {
+		channel      func(ch *slack.Channel)
+		isMultiParty bool
+	}{
+		"im":   {channel: func(ch *slack.Channel) { ch.IsIM = true }, isMultiParty: false},
+		"mpim": {channel: func(ch *slack.Channel) { ch.IsMpIM = true }, isMultiParty: true},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			Before(t)
+			SlackMockClient.AddMockGetUserInfoCall("user-id-123", &slack.User{ID: "user-id-123"}, nil)
+			SlackMockClient.GetConversationInfoFunc = func(channelID string, includeLocale bool) (*slack.Channel, error) {
+				ch := &slack.Channel{}
+				ch.ID = channelID
+				c.channel(ch)
+				return ch, nil
+			}
+
+			SlackImpl.(*slackClient).incomingEvents <- slack.RTMEvent{Type: "message", Data: &slack.MessageEvent{
+				Msg: slack.Msg{Channel: "D123", User: "user-id-123", Text: "deploy foo", Timestamp: "1.0"},
+			}}
+
+			assert.Equal(t, "ReceivedMessage", nextEvent(t).EventDef.Name)
+			dm := nextEvent(t)
+			require.Equal(t, "DirectMessageReceived", dm.EventDef.Name)
+			payload := dm.Payload.(directMessageEvent)
+			assert.Equal(t, c.isMultiParty, payload.IsMultiParty)
+			assert.Equal(t, "deploy foo", payload.Message)
+			assert.Equal(t, "D123", payload.ChannelId)
+			assert.Equal(t, name, payload.ChannelType)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/rs/zerolog/log"
+)
+
+// DownloadedFile holds file details along with its content
+type DownloadedFile struct {
+	ID       string
+	Name     string
+	Mimetype string
+	Size     int
+	Content  []byte
+}
+
+func (sl *slackClient) DownloadFile(fileId string, maxSize int) (*DownloadedFile, error) {
+	f, _, _, err := sl.client.GetFileInfo(fileId, 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get info about file=%s: %v", fileId, err)
+	}
+	if f.Size > maxSize {
+		return nil, fmt.Errorf("file=%s has %d bytes, over download limit of %d bytes", fileId, f.Size, maxSize)
+	}
+
+	// reported size is not trusted, download is cut off once over the limit
+	w := &limitedBuffer{limit: maxSize}
+	if err := sl.client.GetFile(f.URLPrivateDownload, w); err != nil {
+		return nil, fmt.Errorf("cannot download file=%s: %v", fileId, err)
+	}
+
+	log.Info().Msgf("file=%s downloaded, %d bytes", fileId, w.Len())
+	return &DownloadedFile{
+		ID:       f.ID,
+		Name:     f.Name,
+		Mimetype: f.Mimetype,
+		Size:     w.Len(),
+		Content:  w.Bytes(),
+	}, nil
+}
+
+// limitedBuffer fails writes that would grow it over limit bytes
+type limitedBuffer struct {
+	bytes.Buffer
+	limit int
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.Len()+len(p) > b.limit {
+		return 0, fmt.Errorf("download limit of %d bytes exceeded", b.limit)
+	}
+	return b.Buffer.Write(p)
+}
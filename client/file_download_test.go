@@ -0,0 +1,96 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestDownloadFile(t *testing.T) {
+	Before(t)
+	SlackMockClient.GetFileInfoFunc = func(fileID string, count, page int) (*slack.File, []slack.Comment, *slack.Paging, error) {
+		return &slack.File{ID: fileID, Name: "trace.log", Mimetype: "text/plain", Size: 11, URLPrivateDownload: "https://files.slack.com/F123/download"}, nil, nil, nil
+	}
+	SlackMockClient.GetFileFunc = func(downloadURL string, writer io.Writer) error {
+		assert.Equal(t, "https://files.slack.com/F123/download", downloadURL)
+		_, err := writer.Write([]byte("stack trace"))
+		return err
+	}
+
+	f, err := SlackImpl.DownloadFile("F123", 1024)
+
+	require.NoError(t, err)
+	assert.Equal(t, &DownloadedFile{ID: "F123", Name: "trace.log", Mimetype: "text/plain", Size: 11, Content: []byte("stack trace")}, f)
+}
+
+func TestDownloadFileRefusesFilesOverLimit(t *testing.T) {
+	Before(t)
+	SlackMockClient.GetFileInfoFunc = func(fileID string, count, page int) (*slack.File, []slack.Comment, *slack.Paging, error) {
+		return &slack.File{ID: fileID, Size: 2048}, nil, nil, nil
+	}
+
+	_, err := SlackImpl.DownloadFile("F123", 1024)
+
+	assert.EqualError(t, err, "file=F123 has 2048 bytes, over download limit of 1024 bytes")
+}
+
+func TestDownloadFileStopsWhenContentIsOverLimit(t *testing.T) {
+	Before(t)
+	SlackMockClient.GetFileInfoFunc = func(fileID string, count, page int) (*slack.File, []slack.Comment, *slack.Paging, error) {
+		return &slack.File{ID: fileID, Size: 4}, nil, nil, nil
+	}
+	SlackMockClient.GetFileFunc = func(downloadURL string, writer io.Writer) error {
+		_, err := writer.Write([]byte("more than four bytes"))
+		return err
+	}
+
+	_, err := SlackImpl.DownloadFile("F123", 8)
+
+	assert.EqualError(t, err, "cannot download file=F123: download limit of 8 bytes exceeded")
+}
+
+func TestIncomingMessageIncludesFilesAttachmentsAndBlocks(t *testing.T) {
+	Before(t)
+	SlackMockClient.AddMockGetUserInfoCall("user-id-123", &slack.User{ID: "user-id-123"}, nil)
+
+	SlackImpl.(*slackClient).incomingEvents <- slack.RTMEvent{Type: "message", Data: &slack.MessageEvent{
+		Msg: slack.Msg{
+			Channel:     "C123",
+			User:        "user-id-123",
+			Timestamp:   "1.0",
+			Files:       []slack.File{{ID: "F123", Name: "screenshot.png", Mimetype: "image/png", Size: 42, URLPrivate: "https://files.slack.com/F123", Permalink: "https://example.slack.com/files/F123"}},
+			Attachments: []slack.Attachment{{Title: "build failed"}},
+			Blocks:      slack.Blocks{BlockSet: []slack.Block{slack.NewDividerBlock()}},
+		},
+	}}
+
+	select {
+	case msg := <-SlackImpl.IncomingMessages():
+		payload := msg.Payload.(MessageEvent)
+		assert.Equal(t, []file{{Id: "F123", Name: "screenshot.png", Mimetype: "image/png", Size: 42, UrlPrivate: "https://files.slack.com/F123", Permalink: "https://example.slack.com/files/F123"}}, payload.Files)
+		assert.Equal(t, "build failed", payload.Attachments[0].Title)
+		require.Len(t, payload.Blocks.BlockSet, 1)
+		assert.Equal(t, slack.MBTDivider, payload.Blocks.BlockSet[0].BlockType())
+	case <-time.After(250 * time.Millisecond):
+		assert.Fail(t, "expected message event")
+	}
+}
@@ -0,0 +1,53 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"github.com/slack-go/slack"
+	"io"
+)
+
+// FileUpload describes file to be uploaded, file content is streamed from Reader
+type FileUpload struct {
+	Reader          io.Reader
+	Filename        string
+	Filetype        string
+	Title           string
+	InitialComment  string
+	ChannelIds      []string
+	ThreadTimestamp string
+}
+
+func (sl *slackClient) UploadFile(f FileUpload) (string, string, error) {
+	file, err := sl.client.UploadFile(slack.FileUploadParameters{
+		Reader:          f.Reader,
+		Filename:        f.Filename,
+		Filetype:        f.Filetype,
+		Title:           f.Title,
+		InitialComment:  f.InitialComment,
+		Channels:        f.ChannelIds,
+		ThreadTimestamp: f.ThreadTimestamp,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("cannot upload file=%q: %v", f.Filename, err)
+	}
+
+	log.Info().Msgf("file=%q uploaded to channels=%v id=%s", f.Filename, f.ChannelIds, file.ID)
+	return file.ID, file.Permalink, nil
+}
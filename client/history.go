@@ -0,0 +1,97 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"github.com/slack-go/slack"
+)
+
+const historyPageSize = 200 // slack recommends no more than 200
+
+// GetChannelHistory returns up to limit messages newest first, hasMore tells whether older messages got cut off
+func (sl *slackClient) GetChannelHistory(channelId, oldest, latest string, limit int) ([]MessageEvent, bool, error) {
+	params := &slack.GetConversationHistoryParameters{
+		ChannelID: channelId,
+		Oldest:    oldest,
+		Latest:    latest,
+	}
+
+	var msgs []slack.Message
+	for {
+		params.Limit = minInt(historyPageSize, limit-len(msgs))
+		resp, err := sl.client.GetConversationHistory(params)
+		if err != nil {
+			return nil, false, fmt.Errorf("cannot get history of channel=%s: %v", channelId, err)
+		}
+		msgs = append(msgs, resp.Messages...)
+
+		hasMore := resp.HasMore && resp.ResponseMetaData.NextCursor != ""
+		if !hasMore || len(msgs) >= limit {
+			return sl.toMessageEvents(channelId, msgs, limit), hasMore || len(msgs) > limit, nil
+		}
+		params.Cursor = resp.ResponseMetaData.NextCursor
+	}
+}
+
+// GetThreadReplies returns up to limit messages of the thread oldest first, starting with the thread root
+func (sl *slackClient) GetThreadReplies(channelId, threadTimestamp string, limit int) ([]MessageEvent, bool, error) {
+	params := &slack.GetConversationRepliesParameters{
+		ChannelID: channelId,
+		Timestamp: threadTimestamp,
+	}
+
+	var msgs []slack.Message
+	for {
+		params.Limit = minInt(historyPageSize, limit-len(msgs))
+		page, hasMore, cursor, err := sl.client.GetConversationReplies(params)
+		if err != nil {
+			return nil, false, fmt.Errorf("cannot get replies of thread=%s in channel=%s: %v", threadTimestamp, channelId, err)
+		}
+		msgs = append(msgs, page...)
+
+		hasMore = hasMore && cursor != ""
+		if !hasMore || len(msgs) >= limit {
+			return sl.toMessageEvents(channelId, msgs, limit), hasMore || len(msgs) > limit, nil
+		}
+		params.Cursor = cursor
+	}
+}
+
+// toMessageEvents converts at most limit messages, authors and permalinks are resolved the same way as for incoming
+// messages, apart from authors that can't be found being kept as just the id
+func (sl *slackClient) toMessageEvents(channelId string, msgs []slack.Message, limit int) []MessageEvent {
+	if len(msgs) > limit {
+		msgs = msgs[:limit]
+	}
+
+	conv := sl.conversation(channelId)
+	out := make([]MessageEvent, 0, len(msgs))
+	for i := range msgs {
+		e := slack.MessageEvent(msgs[i])
+		e.Channel = channelId
+		u, err := sl.messageAuthor(&e)
+		if err != nil {
+			log.Err(err).Msgf("cannot get info about user=%s", e.User)
+			u = &slack.User{ID: e.User}
+		}
+		permalink := sl.messagePermalink(channelId, e.Timestamp, e.ThreadTimestamp)
+		out = append(out, newMessageEvent(&e, u, conv, permalink, sl.parseMarkup(e.Text)))
+	}
+	return out
+}
@@ -0,0 +1,108 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"errors"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func historyMessage(ts, userId, text string) slack.Message {
+	m := slack.Message{}
+	m.Timestamp = ts
+	m.User = userId
+	m.Text = text
+	return m
+}
+
+func TestGetChannelHistoryPagesUpToLimit(t *testing.T) {
+	Before(t)
+	SlackMockClient.AddMockGetUserInfoCall("U1", &slack.User{ID: "U1", Name: "jdoe"}, nil)
+	var limits []int
+	SlackMockClient.GetConversationHistoryFunc = func(params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+		limits = append(limits, params.Limit)
+		resp := &slack.GetConversationHistoryResponse{HasMore: true}
+		resp.ResponseMetaData.NextCursor = params.Cursor + "x"
+		for i := 0; i < params.Limit; i++ {
+			resp.Messages = append(resp.Messages, historyMessage("1.0", "U1", "status update"))
+		}
+		return resp, nil
+	}
+
+	msgs, hasMore, err := SlackImpl.GetChannelHistory("C123", "", "", 250)
+
+	require.NoError(t, err)
+	assert.True(t, hasMore)
+	assert.Equal(t, []int{200, 50}, limits)
+	require.Len(t, msgs, 250)
+	assert.Equal(t, "C123", msgs[0].ChannelId)
+	assert.Equal(t, "jdoe", msgs[0].User.Name)
+	assert.Equal(t, "status update", msgs[0].PlainText)
+}
+
+func TestGetChannelHistoryBuildsPermalinks(t *testing.T) {
+	Before(t)
+	SlackImpl.(*slackClient).workspaceUrl = "https://acme.slack.com/"
+	SlackMockClient.AddMockGetUserInfoCall("U1", &slack.User{ID: "U1", Name: "jdoe"}, nil)
+	SlackMockClient.GetConversationHistoryFunc = func(params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+		return &slack.GetConversationHistoryResponse{Messages: []slack.Message{historyMessage("1234.5678", "U1", "hi")}}, nil
+	}
+
+	msgs, _, err := SlackImpl.GetChannelHistory("C123", "", "", 10)
+
+	require.NoError(t, err)
+	require.Len(t, msgs, 1)
+	assert.Equal(t, "https://acme.slack.com/archives/C123/p12345678", msgs[0].Permalink)
+}
+
+func TestGetChannelHistoryFails(t *testing.T) {
+	Before(t)
+	SlackMockClient.GetConversationHistoryFunc = func(params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+		return nil, errors.New("not_in_channel")
+	}
+
+	_, _, err := SlackImpl.GetChannelHistory("C123", "", "", 10)
+
+	require.Error(t, err)
+	assert.Equal(t, "cannot get history of channel=C123: not_in_channel", err.Error())
+}
+
+func TestGetThreadRepliesIncludesBotMessages(t *testing.T) {
+	Before(t)
+	SlackMockClient.AddMockGetUserInfoCall("U1", &slack.User{ID: "U1", Name: "jdoe"}, nil)
+	SlackMockClient.GetConversationRepliesFunc = func(params *slack.GetConversationRepliesParameters) ([]slack.Message, bool, string, error) {
+		assert.Equal(t, "1.0", params.Timestamp)
+		bot := historyMessage("1.2", "", "deployed")
+		bot.SubType = "bot_message"
+		bot.BotID = "B1"
+		bot.Username = "deploybot"
+		return []slack.Message{historyMessage("1.0", "U1", "deploy?"), historyMessage("1.1", "U1", "go"), bot}, false, "", nil
+	}
+
+	msgs, hasMore, err := SlackImpl.GetThreadReplies("C123", "1.0", 1000)
+
+	require.NoError(t, err)
+	assert.False(t, hasMore)
+	require.Len(t, msgs, 3)
+	assert.Equal(t, "jdoe", msgs[1].User.Name)
+	assert.Equal(t, "B1", msgs[2].BotId)
+	assert.Equal(t, "", msgs[2].User.Id)
+	assert.Equal(t, "deploybot", msgs[2].User.Name)
+}
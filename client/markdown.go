@@ -0,0 +1,252 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// FormatMrkdwn is slack's own markup, text is sent as is
+	FormatMrkdwn = "mrkdwn"
+	// FormatMarkdown is CommonMark, text is converted to mrkdwn before sending
+	FormatMarkdown = "markdown"
+)
+
+var (
+	mdFenceRegexp      = regexp.MustCompile("^\\s*(```|~~~)")
+	mdHeadingRegexp    = regexp.MustCompile(`^\s{0,3}#{1,6}\s+(.*?)(\s+#+)?\s*$`)
+	mdQuoteRegexp      = regexp.MustCompile(`^\s{0,3}>\s?(.*)$`)
+	mdListItemRegexp   = regexp.MustCompile(`^(\s*)[-*+]\s+(.*)$`)
+	mdRuleRegexp       = regexp.MustCompile(`^\s{0,3}(-(\s*-){2,}|\*(\s*\*){2,}|_(\s*_){2,})\s*$`)
+	mdTableDelimRegexp = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+	mdLinkRegexp       = regexp.MustCompile(`!?\[([^\]]*)\]\(([^)\s]+)(?:\s+"[^"]*")?\)|<((?:https?|mailto):[^>\s]+)>|<([@#!][^>\s]+)>`)
+	mdBoldItalicRegexp = regexp.MustCompile(`\*\*\*(\S(?:.*?\S)?)\*\*\*`)
+	mdBoldRegexp       = regexp.MustCompile(`(\*\*|__)(\S(?:.*?\S)?)(\*\*|__)`)
+	mdItalicRegexp     = regexp.MustCompile(`\*(\S(?:.*?\S)?)\*`)
+	mdStrikeRegexp     = regexp.MustCompile(`~~(\S(?:.*?\S)?)~~`)
+	mrkdwnEscaper      = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+)
+
+// bold marker used while italics are converted, so that "**a**" doesn't end up as "_a_"
+const mdBoldPlaceholder = "\x00"
+
+// FormatText converts text in given format to slack mrkdwn
+func FormatText(text, format string) (string, error) {
+	switch format {
+	case "", FormatMrkdwn:
+		return text, nil
+	case FormatMarkdown:
+		return MarkdownToMrkdwn(text), nil
+	default:
+		return "", fmt.Errorf("unknown format=%q, expected %q or %q", format, FormatMrkdwn, FormatMarkdown)
+	}
+}
+
+// MarkdownToMrkdwn converts CommonMark text into slack mrkdwn. Headings become bold lines,
+// tables are rendered as aligned code blocks and &, < and > are escaped everywhere apart
+// from slack mentions such as <@U123>, which are kept as they are.
+func MarkdownToMrkdwn(markdown string) string {
+	lines := strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n")
+	out := make([]string, 0, len(lines))
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		if mdFenceRegexp.MatchString(line) {
+			end := i + 1
+			for end < len(lines) && !mdFenceRegexp.MatchString(lines[end]) {
+				end++
+			}
+			out = append(out, "```")
+			for _, l := range lines[i+1 : minInt(end, len(lines))] {
+				out = append(out, mrkdwnEscaper.Replace(l))
+			}
+			out = append(out, "```")
+			i = end
+			continue
+		}
+
+		if i+1 < len(lines) && isMarkdownTable(line, lines[i+1]) {
+			end := i + 2
+			for end < len(lines) && strings.Contains(lines[end], "|") && strings.TrimSpace(lines[end]) != "" {
+				end++
+			}
+			out = append(out, markdownTable(line, lines[i+1], lines[i+2:end])...)
+			i = end - 1
+			continue
+		}
+
+		out = append(out, markdownLine(line))
+	}
+
+	return strings.Join(out, "\n")
+}
+
+func markdownLine(line string) string {
+	if m := mdHeadingRegexp.FindStringSubmatch(line); m != nil {
+		return "*" + markdownInline(m[1]) + "*"
+	}
+	if mdRuleRegexp.MatchString(line) {
+		return "──────────"
+	}
+	if m := mdListItemRegexp.FindStringSubmatch(line); m != nil {
+		return m[1] + "• " + markdownInline(m[2])
+	}
+	if m := mdQuoteRegexp.FindStringSubmatch(line); m != nil {
+		return "> " + markdownInline(m[1])
+	}
+	return markdownInline(line)
+}
+
+// markdownInline converts inline markup, leaving code spans untouched apart from escaping
+func markdownInline(text string) string {
+	var b strings.Builder
+	for i, segment := range strings.Split(text, "`") {
+		if i > 0 {
+			b.WriteString("`")
+		}
+		if i%2 == 1 {
+			b.WriteString(mrkdwnEscaper.Replace(segment))
+			continue
+		}
+		b.WriteString(markdownLinks(segment))
+	}
+	return b.String()
+}
+
+func markdownLinks(text string) string {
+	var b strings.Builder
+	last := 0
+	for _, m := range mdLinkRegexp.FindAllStringSubmatchIndex(text, -1) {
+		b.WriteString(markdownEmphasis(text[last:m[0]]))
+		if m[8] >= 0 {
+			// slack mention, <@U123>, <#C123|name> or <!here>
+			b.WriteString(text[m[0]:m[1]])
+		} else if m[6] >= 0 {
+			// autolink, <https://example.com>
+			b.WriteString("<" + mrkdwnEscaper.Replace(text[m[6]:m[7]]) + ">")
+		} else {
+			url := mrkdwnEscaper.Replace(text[m[4]:m[5]])
+			label := mrkdwnEscaper.Replace(text[m[2]:m[3]])
+			if label == "" || label == url {
+				b.WriteString("<" + url + ">")
+			} else {
+				b.WriteString("<" + url + "|" + label + ">")
+			}
+		}
+		last = m[1]
+	}
+	b.WriteString(markdownEmphasis(text[last:]))
+	return b.String()
+}
+
+func markdownEmphasis(text string) string {
+	text = mrkdwnEscaper.Replace(text)
+	text = mdBoldItalicRegexp.ReplaceAllString(text, mdBoldPlaceholder+"_${1}_"+mdBoldPlaceholder)
+	text = mdBoldRegexp.ReplaceAllString(text, mdBoldPlaceholder+"$2"+mdBoldPlaceholder)
+	text = mdItalicRegexp.ReplaceAllString(text, "_${1}_")
+	text = mdStrikeRegexp.ReplaceAllString(text, "~$1~")
+	return strings.ReplaceAll(text, mdBoldPlaceholder, "*")
+}
+
+// isMarkdownTable tells whether lines start a table, i.e. the delimiter row has a pipe and as many columns as the header,
+// so that text followed by a bare --- rule isn't taken for one
+func isMarkdownTable(header, delimiter string) bool {
+	if !strings.Contains(header, "|") || !strings.Contains(delimiter, "|") || !mdTableDelimRegexp.MatchString(delimiter) {
+		return false
+	}
+	return len(markdownTableCells(header)) == len(markdownTableCells(delimiter))
+}
+
+// markdownTable renders table as code block with columns padded to the same width
+func markdownTable(header, delimiter string, rows []string) []string {
+	table := [][]string{markdownTableCells(header)}
+	for _, r := range rows {
+		table = append(table, markdownTableCells(r))
+	}
+
+	aligns := markdownTableCells(delimiter)
+	widths := make([]int, len(table[0]))
+	for _, row := range table {
+		for c := 0; c < len(row) && c < len(widths); c++ {
+			widths[c] = maxInt(widths[c], utf8.RuneCountInString(row[c]))
+		}
+	}
+
+	out := []string{"```"}
+	for r, row := range table {
+		cells := make([]string, len(widths))
+		for c := range widths {
+			cell := ""
+			if c < len(row) {
+				cell = row[c]
+			}
+			pad := strings.Repeat(" ", widths[c]-utf8.RuneCountInString(cell))
+			if c < len(aligns) && isRightAligned(aligns[c]) {
+				cells[c] = pad + cell
+			} else {
+				cells[c] = cell + pad
+			}
+		}
+		out = append(out, mrkdwnEscaper.Replace(strings.TrimRight(strings.Join(cells, " | "), " ")))
+
+		if r == 0 {
+			rule := make([]string, len(widths))
+			for c := range widths {
+				rule[c] = strings.Repeat("-", widths[c])
+			}
+			out = append(out, strings.Join(rule, "-+-"))
+		}
+	}
+	return append(out, "```")
+}
+
+func isRightAligned(delimiter string) bool {
+	return strings.HasSuffix(delimiter, ":") && !strings.HasPrefix(delimiter, ":")
+}
+
+// markdownTableCells splits table row into cells, stripping inline markup which code block wouldn't render
+func markdownTableCells(row string) []string {
+	row = strings.TrimSpace(row)
+	row = strings.TrimPrefix(row, "|")
+	row = strings.TrimSuffix(row, "|")
+
+	cells := strings.Split(strings.ReplaceAll(row, `\|`, "\x00"), "|")
+	for i := range cells {
+		cell := strings.ReplaceAll(cells[i], "\x00", "|")
+		cells[i] = strings.NewReplacer("**", "", "__", "", "`", "").Replace(strings.TrimSpace(cell))
+	}
+	return cells
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,114 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"encoding/json"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestMarkdownToMrkdwn(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		expected string
+	}{
+		{name: "bold", markdown: "**deploy** __failed__", expected: "*deploy* *failed*"},
+		{name: "italic", markdown: "*really* _really_", expected: "_really_ _really_"},
+		{name: "bold and italic", markdown: "***both***", expected: "*_both_*"},
+		{name: "strikethrough", markdown: "~~old~~", expected: "~old~"},
+		{name: "link", markdown: "see [PR #12](https://github.com/org/repo/pull/12)", expected: "see <https://github.com/org/repo/pull/12|PR #12>"},
+		{name: "autolink", markdown: "<https://example.com?a=1&b=2>", expected: "<https://example.com?a=1&amp;b=2>"},
+		{name: "heading", markdown: "## Release **1.2** ##", expected: "*Release *1.2**"},
+		{name: "list", markdown: "- one\n  * two", expected: "• one\n  • two"},
+		{name: "quote", markdown: "> quoted & <b>", expected: "> quoted &amp; &lt;b&gt;"},
+		{name: "rule", markdown: "---", expected: "──────────"},
+		{name: "slack mentions", markdown: "<@U123> <#C123|general> <!subteam^S123> <!here>", expected: "<@U123> <#C123|general> <!subteam^S123> <!here>"},
+		{name: "escaping", markdown: "a < b && b > c", expected: "a &lt; b &amp;&amp; b &gt; c"},
+		{name: "inline code", markdown: "run `**x** < y`", expected: "run `**x** &lt; y`"},
+		{
+			name:     "code block",
+			markdown: "```go\nif a < b {\n**x**\n```",
+			expected: "```\nif a &lt; b {\n**x**\n```",
+		},
+		{
+			name:     "unterminated code block",
+			markdown: "```\nfoo",
+			expected: "```\nfoo\n```",
+		},
+		{
+			name:     "table",
+			markdown: "| Service | Errors |\n|:--|--:|\n| **api** | 12 |\n| web & cdn | 3 |\nafter",
+			expected: "```\nService   | Errors\n----------+-------\napi       |     12\nweb &amp; cdn |      3\n```\nafter",
+		},
+		{
+			name:     "text with pipe above rule",
+			markdown: "a | b\n---",
+			expected: "a | b\n──────────",
+		},
+		{
+			name:     "delimiter with other column count",
+			markdown: "| a | b |\n|---|",
+			expected: "| a | b |\n|---|",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, MarkdownToMrkdwn(test.markdown))
+		})
+	}
+}
+
+func TestFormatTextRejectsUnknownFormat(t *testing.T) {
+	_, err := FormatText("hi", "html")
+
+	require.Error(t, err)
+	assert.Equal(t, `unknown format="html", expected "mrkdwn" or "markdown"`, err.Error())
+}
+
+func TestSendRichMessageConvertsMarkdown(t *testing.T) {
+	Before(t)
+
+	var values map[string][]string
+	SlackMockClient.PostMessageFunc = func(channel string, opts ...slack.MsgOption) (string, string, error) {
+		_, v, err := slack.UnsafeApplyMsgOptions("token", channel, "https://slack.com/api/", opts...)
+		require.NoError(t, err)
+		values = v
+		return "", "", nil
+	}
+
+	rm := RichMessage{
+		ChannelID:   "channel id",
+		Text:        "**build** failed",
+		EscapeText:  true,
+		Format:      FormatMarkdown,
+		Attachments: []slack.Attachment{{Text: "[logs](https://ci/1)"}},
+	}
+	_, _, err := SlackImpl.SendRichMessage(rm)
+	require.NoError(t, err)
+
+	assert.Equal(t, "*build* failed", values["text"][0])
+	var attachments []slack.Attachment
+	require.NoError(t, json.Unmarshal([]byte(values["attachments"][0]), &attachments))
+	assert.Equal(t, "<https://ci/1|logs>", attachments[0].Text)
+	// input is not modified
+	assert.Equal(t, "[logs](https://ci/1)", rm.Attachments[0].Text)
+}
@@ -0,0 +1,100 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"github.com/slack-go/slack"
+	"sync"
+	"time"
+)
+
+// defaultAckTimeout is how long SendMessage waits for slack to acknowledge a message sent over RTM
+const defaultAckTimeout = 5 * time.Second
+
+type ackResult struct {
+	timestamp string
+	err       error
+}
+
+// acks passes RTM acknowledgements from the incoming events router to senders waiting for them
+type acks struct {
+	mu      sync.Mutex
+	pending map[int]chan ackResult
+}
+
+func newAcks() *acks {
+	return &acks{pending: make(map[int]chan ackResult)}
+}
+
+// expect has to be called before the message is sent, so that the ack can't arrive before anybody waits for it
+func (a *acks) expect(messageId int) <-chan ackResult {
+	ch := make(chan ackResult, 1)
+	a.mu.Lock()
+	a.pending[messageId] = ch
+	a.mu.Unlock()
+	return ch
+}
+
+// deliver hands the ack to the sender, acks nobody waits for (e.g. after timeout) are dropped
+func (a *acks) deliver(messageId int, r ackResult) {
+	a.mu.Lock()
+	ch, ok := a.pending[messageId]
+	delete(a.pending, messageId)
+	a.mu.Unlock()
+	if ok {
+		ch <- r
+	}
+}
+
+// handle delivers event to the sender waiting for it, it's false for events other than acks
+func (a *acks) handle(event slack.RTMEvent) bool {
+	switch v := event.Data.(type) {
+	case *slack.AckMessage:
+		a.deliver(v.ReplyTo, ackResult{timestamp: v.Timestamp})
+		return true
+	case *slack.AckErrorEvent:
+		log.Err(v).Msgf("slack refused message=%d", v.ReplyTo)
+		a.deliver(v.ReplyTo, ackResult{err: v})
+		return true
+	}
+	return false
+}
+
+// wait returns timestamp of the acknowledged message. Message that isn't acknowledged in time is unconfirmed,
+// slack may still refuse it, so errNoAck is returned instead of treating it as sent.
+func (a *acks) wait(messageId int, ch <-chan ackResult, timeout time.Duration) (string, error) {
+	select {
+	case r := <-ch:
+		return r.timestamp, r.err
+	case <-time.After(timeout):
+		a.mu.Lock()
+		delete(a.pending, messageId)
+		a.mu.Unlock()
+		return "", &errNoAck{timeout: timeout}
+	}
+}
+
+// errNoAck means slack didn't acknowledge the message in time, it may or may not have been sent
+type errNoAck struct {
+	timeout time.Duration
+}
+
+func (e *errNoAck) Error() string {
+	return fmt.Sprintf("no ack received in %s, message may not have been sent", e.timeout)
+}
@@ -0,0 +1,155 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"github.com/rs/zerolog/log"
+	"regexp"
+	"strings"
+)
+
+// markupRegexp matches slack control sequences such as <@U123>, <#C123|general>, <!here> or <https://example.com|example>
+var markupRegexp = regexp.MustCompile(`<([^<>]+)>`)
+
+var markupUnescaper = strings.NewReplacer("&amp;", "&", "&lt;", "<", "&gt;", ">")
+
+// specialMentions are the only <!...> commands that notify channel members
+var specialMentions = map[string]bool{"!here": true, "!channel": true, "!everyone": true}
+
+type channelMention struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type userGroupMention struct {
+	Id     string `json:"id"`
+	Handle string `json:"handle"`
+}
+
+type link struct {
+	Url  string `json:"url"`
+	Text string `json:"text"`
+}
+
+// messageMarkup holds mentions and links parsed out of message text
+type messageMarkup struct {
+	Mentions          []User             `json:"mentions"`
+	ChannelMentions   []channelMention   `json:"channelMentions"`
+	SpecialMentions   []string           `json:"specialMentions"`
+	UserGroupMentions []userGroupMention `json:"userGroupMentions"`
+	Links             []link             `json:"links"`
+	// PlainText is the message with markup replaced by readable names
+	PlainText string `json:"plainText"`
+}
+
+// parseMarkup extracts mentions and links from text, resolving users and channel names through slack
+func (sl *slackClient) parseMarkup(text string) messageMarkup {
+	out := messageMarkup{
+		Mentions:          []User{},
+		ChannelMentions:   []channelMention{},
+		SpecialMentions:   []string{},
+		UserGroupMentions: []userGroupMention{},
+		Links:             []link{},
+	}
+	seen := map[string]bool{}
+
+	plain := markupRegexp.ReplaceAllStringFunc(text, func(m string) string {
+		target, label := splitMarkup(m[1 : len(m)-1])
+		first := !seen[target]
+		seen[target] = true
+
+		switch {
+		case strings.HasPrefix(target, "@"):
+			u := sl.mentionedUser(target[1:])
+			if first {
+				out.Mentions = append(out.Mentions, u)
+			}
+			return "@" + u.Name
+
+		case strings.HasPrefix(target, "#"):
+			c := channelMention{Id: target[1:], Name: label}
+			if c.Name == "" {
+				c.Name = sl.conversation(c.Id).Name
+			}
+			if first {
+				out.ChannelMentions = append(out.ChannelMentions, c)
+			}
+			return "#" + c.Name
+
+		case strings.HasPrefix(target, "!subteam^"):
+			g := userGroupMention{Id: strings.TrimPrefix(target, "!subteam^"), Handle: strings.TrimPrefix(label, "@")}
+			if g.Handle == "" {
+				g.Handle = sl.userGroupHandle(g.Id)
+			}
+			if first {
+				out.UserGroupMentions = append(out.UserGroupMentions, g)
+			}
+			return "@" + g.Handle
+
+		case specialMentions[target]:
+			if first {
+				out.SpecialMentions = append(out.SpecialMentions, target[1:])
+			}
+			return "@" + target[1:]
+
+		case strings.HasPrefix(target, "!"):
+			// other commands such as <!date^1392734382^{date_short}|Feb 18, 2014> are rendered as their fallback label
+			return label
+
+		default:
+			l := link{Url: markupUnescaper.Replace(target), Text: markupUnescaper.Replace(label)}
+			if first {
+				out.Links = append(out.Links, l)
+			}
+			if l.Text != "" {
+				return l.Text
+			}
+			return strings.TrimPrefix(l.Url, "mailto:")
+		}
+	})
+
+	out.PlainText = markupUnescaper.Replace(plain)
+	return out
+}
+
+// splitMarkup splits "target|label" control sequence content
+func splitMarkup(s string) (target, label string) {
+	if i := strings.Index(s, "|"); i >= 0 {
+		return s[:i], s[i+1:]
+	}
+	return s, ""
+}
+
+// mentionedUser resolves mentioned user, falling back to just the id
+func (sl *slackClient) mentionedUser(userId string) User {
+	u, err := sl.userInfo(userId)
+	if err != nil {
+		log.Err(err).Msgf("cannot get info about mentioned user=%s", userId)
+		return User{Id: userId, Name: userId}
+	}
+	return newUser(u)
+}
+
+// userGroupHandle resolves handle of mentioned user group through the cache, falling back to just the id
+func (sl *slackClient) userGroupHandle(userGroupId string) string {
+	g, err := sl.cache.GetUserGroupById(userGroupId, sl)
+	if err != nil {
+		log.Err(err).Msgf("cannot get info about mentioned user group=%s", userGroupId)
+		return userGroupId
+	}
+	return g.Handle
+}
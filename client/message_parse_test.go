@@ -0,0 +1,83 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"errors"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestIncomingMessageHasParsedMentionsAndLinks(t *testing.T) {
+	Before(t)
+	SlackMockClient.AddMockGetUserInfoCall("user-id-123", &slack.User{ID: "user-id-123"}, nil)
+	SlackMockClient.AddMockGetUserInfoCall("U1", &slack.User{ID: "U1", Name: "jdoe", Profile: slack.UserProfile{Email: "jdoe@example.com"}}, nil)
+	SlackMockClient.GetConversationInfoFunc = func(channelID string, includeLocale bool) (*slack.Channel, error) {
+		ch := &slack.Channel{}
+		ch.ID = channelID
+		ch.Name = "ops"
+		return ch, nil
+	}
+
+	SlackImpl.(*slackClient).incomingEvents <- slack.RTMEvent{Type: "message", Data: &slack.MessageEvent{
+		Msg: slack.Msg{
+			Channel:   "C123",
+			User:      "user-id-123",
+			Text:      "<!here> <@U1> see <#C1|incidents> and <#C2>, cc <!subteam^S1|@sre> &amp; <https://example.com/a?b=1&amp;c=2|runbook> <mailto:a@example.com>",
+			Timestamp: "1.0",
+		},
+	}}
+
+	select {
+	case msg := <-SlackImpl.IncomingMessages():
+		payload := msg.Payload.(MessageEvent)
+		assert.Equal(t, []User{{Id: "U1", Name: "jdoe", Email: "jdoe@example.com"}}, payload.Mentions)
+		assert.Equal(t, []channelMention{{Id: "C1", Name: "incidents"}, {Id: "C2", Name: "ops"}}, payload.ChannelMentions)
+		assert.Equal(t, []string{"here"}, payload.SpecialMentions)
+		assert.Equal(t, []userGroupMention{{Id: "S1", Handle: "sre"}}, payload.UserGroupMentions)
+		assert.Equal(t, []link{{Url: "https://example.com/a?b=1&c=2", Text: "runbook"}, {Url: "mailto:a@example.com"}}, payload.Links)
+		assert.Equal(t, "@here @jdoe see #incidents and #ops, cc @sre & runbook a@example.com", payload.PlainText)
+	case <-time.After(250 * time.Millisecond):
+		assert.Fail(t, "expected message event")
+	}
+}
+
+func TestParseMarkupFallsBackToUserIdWhenUserCannotBeFound(t *testing.T) {
+	Before(t)
+	SlackMockClient.AddMockGetUserInfoCall("U404", nil, errors.New("user_not_found"))
+
+	markup := SlackImpl.(*slackClient).parseMarkup("hi <@U404>")
+
+	assert.Equal(t, []User{{Id: "U404", Name: "U404"}}, markup.Mentions)
+	assert.Equal(t, "hi @U404", markup.PlainText)
+	assert.Empty(t, markup.Links)
+}
+
+func TestParseMarkupOnlyTreatsHereChannelAndEveryoneAsSpecialMentions(t *testing.T) {
+	Before(t)
+	SlackMockClient.GetUserGroupsFunc = func(options ...slack.GetUserGroupsOption) ([]slack.UserGroup, error) {
+		return []slack.UserGroup{{ID: "S1", Handle: "sre-oncall"}}, nil
+	}
+
+	markup := SlackImpl.(*slackClient).parseMarkup("<!channel> <!everyone|@everyone> deploy at <!date^1392734382^{date_short} {time}|Feb 18, 2014 6:39 AM>, ping <!subteam^S1>")
+
+	assert.Equal(t, []string{"channel", "everyone"}, markup.SpecialMentions)
+	assert.Equal(t, []userGroupMention{{Id: "S1", Handle: "sre-oncall"}}, markup.UserGroupMentions)
+	assert.Equal(t, "@channel @everyone deploy at Feb 18, 2014 6:39 AM, ping @sre-oncall", markup.PlainText)
+}
@@ -0,0 +1,100 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// maxMessageLength is slack's recommended limit for message text, longer messages are split
+	maxMessageLength = 4000
+	codeFence        = "```"
+)
+
+// splitMessage splits text into chunks not longer than limit bytes. Text is split at line boundaries
+// where possible and code fences left open at the end of a chunk are closed and reopened in the next one.
+func splitMessage(text string, limit int) []string {
+	if len(text) <= limit {
+		return []string{text}
+	}
+
+	// leave room for reopening and closing code fence in every chunk
+	budget := limit - 2*len(codeFence+"\n")
+
+	var chunks, lines []string
+	size := 0
+	inFence, reopened := false, false
+
+	flush := func() {
+		if len(lines) == 0 {
+			return
+		}
+		chunk := strings.Join(lines, "\n")
+		if reopened {
+			chunk = codeFence + "\n" + chunk
+		}
+		if inFence {
+			chunk += "\n" + codeFence
+		}
+		chunks = append(chunks, chunk)
+		lines, size, reopened = nil, 0, inFence
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		for i, part := range splitLine(line, budget) {
+			if i > 0 || (len(lines) > 0 && size+len("\n")+len(part) > budget) {
+				flush()
+			}
+			if len(lines) > 0 {
+				size += len("\n")
+			}
+			lines = append(lines, part)
+			size += len(part)
+			// fence state is tracked per part, as parts of a long line may end up in different chunks
+			if strings.Count(part, codeFence)%2 == 1 {
+				inFence = !inFence
+			}
+		}
+	}
+
+	// fence left open by the text itself is not ours to close
+	inFence = false
+	flush()
+	return chunks
+}
+
+// splitLine splits line longer than limit without breaking multi-byte characters or code fences
+func splitLine(line string, limit int) []string {
+	var parts []string
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		for i := cut - len(codeFence) + 1; i < cut; i++ {
+			if i > 0 && strings.HasPrefix(line[i:], codeFence) {
+				cut = i
+				break
+			}
+		}
+		parts = append(parts, line[:cut])
+		line = line[cut:]
+	}
+	return append(parts, line)
+}
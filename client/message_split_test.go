@@ -0,0 +1,130 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestSplitMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		limit    int
+		expected []string
+	}{
+		{
+			name:     "short message is not split",
+			text:     "line 1\nline 2",
+			limit:    100,
+			expected: []string{"line 1\nline 2"},
+		},
+		{
+			name:     "split at line boundaries",
+			text:     "line 1\nline 2\nline 3",
+			limit:    19,
+			expected: []string{"line 1", "line 2", "line 3"},
+		},
+		{
+			name:     "code fence is closed and reopened",
+			text:     "trace:\n```\nat a\nat b\n```\ndone",
+			limit:    26,
+			expected: []string{"trace:\n```\nat a\n```", "```\nat b\n```\ndone"},
+		},
+		{
+			name:     "long line is split without breaking characters",
+			text:     strings.Repeat("é", 10),
+			limit:    17,
+			expected: []string{"éééé", "éééé", "éé"},
+		},
+		{
+			name:     "code fence in long line is closed and reopened",
+			text:     "see ```" + strings.Repeat("x", 20) + "``` ok",
+			limit:    24,
+			expected: []string{"see ```xxxxxxxxx\n```", "```\nxxxxxxxxxxx``` o", "k"},
+		},
+		{
+			name:     "long line is not split inside code fence",
+			text:     strings.Repeat("x", 15) + "```" + strings.Repeat("y", 10) + "```",
+			limit:    24,
+			expected: []string{strings.Repeat("x", 15), "```" + strings.Repeat("y", 10) + "```"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			chunks := splitMessage(test.text, test.limit)
+
+			assert.Equal(t, test.expected, chunks)
+			for _, c := range chunks {
+				assert.LessOrEqual(t, len(c), test.limit)
+				assert.Equal(t, 0, strings.Count(c, codeFence)%2, "unbalanced code fence in %q", c)
+			}
+		})
+	}
+}
+
+func TestSendMessageSplitsLongMessageIntoThread(t *testing.T) {
+	Before(t)
+
+	var posted []url.Values
+	SlackMockClient.PostMessageFunc = func(channel string, opts ...slack.MsgOption) (string, string, error) {
+		_, values, err := slack.UnsafeApplyMsgOptions("token", channel, "https://slack.com/api/", opts...)
+		require.NoError(t, err)
+		posted = append(posted, values)
+		return channel, "first.ts", nil
+	}
+
+	line := strings.Repeat("x", 99)
+	ts, err := SlackImpl.SendMessage(strings.Repeat(line+"\n", 50), "channel id", "")
+
+	require.NoError(t, err)
+	assert.Equal(t, "first.ts", ts)
+	require.Equal(t, 2, len(posted))
+	assert.Equal(t, "", posted[0].Get("thread_ts"))
+	assert.Equal(t, "first.ts", posted[1].Get("thread_ts"))
+}
+
+func TestSendMessageUploadsSnippetAboveThreshold(t *testing.T) {
+	Before(t)
+	SlackImpl.(*slackClient).cfg.SnippetThreshold = 10
+
+	var uploaded slack.FileUploadParameters
+	SlackMockClient.UploadFileFunc = func(params slack.FileUploadParameters) (*slack.File, error) {
+		uploaded = params
+		f := &slack.File{ID: "F123"}
+		f.Shares.Public = map[string][]slack.ShareFileInfo{"channel id": {{Ts: "snippet.ts"}}}
+		return f, nil
+	}
+	SlackMockClient.PostMessageFunc = func(channel string, opts ...slack.MsgOption) (string, string, error) {
+		t.Fatal("snippet must not be posted as a message")
+		return "", "", nil
+	}
+
+	ts, err := SlackImpl.SendMessage("a rather long stack trace", "channel id", "thread")
+
+	require.NoError(t, err)
+	assert.Equal(t, "snippet.ts", ts)
+	assert.Equal(t, "a rather long stack trace", uploaded.Content)
+	assert.Equal(t, []string{"channel id"}, uploaded.Channels)
+	assert.Equal(t, "thread", uploaded.ThreadTimestamp)
+}
@@ -0,0 +1,108 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+	"github.com/ExpediaGroup/flyte-client/flyte"
+	"github.com/ExpediaGroup/flyte-slack/types"
+	"github.com/rs/zerolog/log"
+	"github.com/slack-go/slack"
+)
+
+// PinMessage pins message to channel, pinning already pinned message is not an error
+func (sl *slackClient) PinMessage(channelId, timestamp string) error {
+	err := sl.client.AddPin(channelId, slack.NewRefToMessage(channelId, timestamp))
+	if err != nil && err.Error() != "already_pinned" {
+		return fmt.Errorf("cannot pin message=%s in channel=%s: %v", timestamp, channelId, err)
+	}
+
+	log.Info().Msgf("message=%s pinned in channel=%s", timestamp, channelId)
+	return nil
+}
+
+// UnpinMessage removes message from channel pins, unpinning message that isn't pinned is not an error
+func (sl *slackClient) UnpinMessage(channelId, timestamp string) error {
+	err := sl.client.RemovePin(channelId, slack.NewRefToMessage(channelId, timestamp))
+	if err != nil && err.Error() != "no_pin" {
+		return fmt.Errorf("cannot unpin message=%s in channel=%s: %v", timestamp, channelId, err)
+	}
+
+	log.Info().Msgf("message=%s unpinned in channel=%s", timestamp, channelId)
+	return nil
+}
+
+func (sl *slackClient) ListPins(channelId string) ([]types.Pin, error) {
+	items, _, err := sl.client.ListPins(channelId)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list pins in channel=%s: %v", channelId, err)
+	}
+
+	out := make([]types.Pin, 0, len(items))
+	for _, i := range items {
+		out = append(out, toPin(i))
+	}
+	return out, nil
+}
+
+func toPin(i slack.Item) types.Pin {
+	p := types.Pin{Type: i.Type, Timestamp: i.Timestamp}
+	if i.Message != nil {
+		p.Timestamp = i.Message.Timestamp
+		p.User = i.Message.User
+		p.Text = i.Message.Text
+	}
+	if i.File != nil {
+		p.FileID = i.File.ID
+		p.User = i.File.User
+	}
+	return p
+}
+
+type pinEvent struct {
+	ChannelId   string    `json:"channelId"`
+	ChannelName string    `json:"channelName"`
+	User        User      `json:"user"`
+	Item        types.Pin `json:"item"`
+}
+
+func toFlytePinEvent(name string, channelId string, u *slack.User, item slack.Item, conv types.Conversation) flyte.Event {
+	return flyte.Event{
+		EventDef: flyte.EventDef{Name: name},
+		Payload: pinEvent{
+			ChannelId:   channelId,
+			ChannelName: conv.Name,
+			User:        newUser(u),
+			Item:        toPin(item),
+		},
+	}
+}
+
+// starEvent has the same fields as pinEvent, channel is empty for items starred outside a channel such as files
+type starEvent pinEvent
+
+func toFlyteStarEvent(name string, u *slack.User, item slack.Item, conv types.Conversation) flyte.Event {
+	return flyte.Event{
+		EventDef: flyte.EventDef{Name: name},
+		Payload: starEvent{
+			ChannelId:   item.Channel,
+			ChannelName: conv.Name,
+			User:        newUser(u),
+			Item:        toPin(item),
+		},
+	}
+}
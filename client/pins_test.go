@@ -0,0 +1,107 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"errors"
+	"github.com/ExpediaGroup/flyte-slack/types"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestPinMessageIgnoresAlreadyPinned(t *testing.T) {
+	Before(t)
+	SlackMockClient.AddPinFunc = func(channel string, item slack.ItemRef) error {
+		assert.Equal(t, slack.NewRefToMessage("C123", "1.0"), item)
+		return errors.New("already_pinned")
+	}
+
+	assert.NoError(t, SlackImpl.PinMessage("C123", "1.0"))
+}
+
+func TestUnpinMessageIgnoresNoPin(t *testing.T) {
+	Before(t)
+	SlackMockClient.RemovePinFunc = func(channel string, item slack.ItemRef) error {
+		return errors.New("no_pin")
+	}
+
+	assert.NoError(t, SlackImpl.UnpinMessage("C123", "1.0"))
+}
+
+func TestUnpinMessageReturnsOtherErrors(t *testing.T) {
+	Before(t)
+	SlackMockClient.RemovePinFunc = func(channel string, item slack.ItemRef) error {
+		return errors.New("not_in_channel")
+	}
+
+	assert.EqualError(t, SlackImpl.UnpinMessage("C123", "1.0"), "cannot unpin message=1.0 in channel=C123: not_in_channel")
+}
+
+func TestListPins(t *testing.T) {
+	Before(t)
+	SlackMockClient.ListPinsFunc = func(channel string) ([]slack.Item, *slack.Paging, error) {
+		msg := slack.NewMessageItem(channel, &slack.Message{Msg: slack.Msg{Timestamp: "1.0", User: "U1", Text: "status: mitigated"}})
+		file := slack.NewFileItem(&slack.File{ID: "F1", User: "U2"})
+		return []slack.Item{msg, file}, nil, nil
+	}
+
+	pins, err := SlackImpl.ListPins("C123")
+
+	require.NoError(t, err)
+	assert.Equal(t, []types.Pin{
+		{Type: "message", Timestamp: "1.0", User: "U1", Text: "status: mitigated"},
+		{Type: "file", User: "U2", FileID: "F1"},
+	}, pins)
+}
+
+func TestPinAddedEvent(t *testing.T) {
+	Before(t)
+	SlackMockClient.AddMockGetUserInfoCall("U1", &slack.User{ID: "U1", Name: "jdoe"}, nil)
+
+	SlackImpl.(*slackClient).incomingEvents <- slack.RTMEvent{Type: "pin_added", Data: &slack.PinAddedEvent{
+		User:    "U1",
+		Channel: "C123",
+		Item:    slack.NewMessageItem("C123", &slack.Message{Msg: slack.Msg{Timestamp: "1.0", User: "U2", Text: "status: mitigated"}}),
+	}}
+
+	e := nextEvent(t)
+	require.Equal(t, "PinAdded", e.EventDef.Name)
+	payload := e.Payload.(pinEvent)
+	assert.Equal(t, "C123", payload.ChannelId)
+	assert.Equal(t, "jdoe", payload.User.Name)
+	assert.Equal(t, types.Pin{Type: "message", Timestamp: "1.0", User: "U2", Text: "status: mitigated"}, payload.Item)
+}
+
+func TestStarAddedAndRemovedEvents(t *testing.T) {
+	Before(t)
+	SlackMockClient.AddMockGetUserInfoCall("U1", &slack.User{ID: "U1", Name: "jdoe"}, nil)
+	item := slack.StarredItem(slack.NewMessageItem("C123", &slack.Message{Msg: slack.Msg{Timestamp: "1.0", User: "U2", Text: "status: mitigated"}}))
+
+	SlackImpl.(*slackClient).incomingEvents <- slack.RTMEvent{Type: "star_added", Data: &slack.StarAddedEvent{User: "U1", Item: item}}
+	SlackImpl.(*slackClient).incomingEvents <- slack.RTMEvent{Type: "star_removed", Data: &slack.StarRemovedEvent{User: "U1", Item: item}}
+
+	for _, name := range []string{"StarAdded", "StarRemoved"} {
+		e := nextEvent(t)
+		require.Equal(t, name, e.EventDef.Name)
+		payload := e.Payload.(starEvent)
+		assert.Equal(t, "C123", payload.ChannelId)
+		assert.Equal(t, "jdoe", payload.User.Name)
+		assert.Equal(t, types.Pin{Type: "message", Timestamp: "1.0", User: "U2", Text: "status: mitigated"}, payload.Item)
+	}
+}
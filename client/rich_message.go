@@ -33,6 +33,8 @@ type RichMessage struct {
 	EscapeText      bool               `json:"escape_text"`
 	ChannelID       string             `json:"channel"`
 	Text            string             `json:"text"`
+	// Format of Text and attachment texts, either "mrkdwn" (default) or "markdown"
+	Format string `json:"format,omitempty"`
 }
 
 type MessagePoster interface {
@@ -40,9 +42,42 @@ type MessagePoster interface {
 }
 
 func (m RichMessage) Post(rtm MessagePoster) (respChannel string, respTimestamp string, err error) {
+	if m, err = m.formatted(); err != nil {
+		return "", "", err
+	}
 	return rtm.PostMessage(m.ChannelID, m.toMsgOptions()...)
 }
 
+// formatted returns copy of the message with text and attachments converted to mrkdwn
+func (m RichMessage) formatted() (RichMessage, error) {
+	if m.Format == "" || m.Format == FormatMrkdwn {
+		return m, nil
+	}
+
+	var err error
+	if m.Text, err = FormatText(m.Text, m.Format); err != nil {
+		return m, err
+	}
+	// converted text is already escaped
+	m.EscapeText = false
+
+	attachments := make([]slack.Attachment, len(m.Attachments))
+	for i, a := range m.Attachments {
+		a.Pretext = MarkdownToMrkdwn(a.Pretext)
+		a.Text = MarkdownToMrkdwn(a.Text)
+		fields := make([]slack.AttachmentField, len(a.Fields))
+		for j, f := range a.Fields {
+			f.Value = MarkdownToMrkdwn(f.Value)
+			fields[j] = f
+		}
+		a.Fields = fields
+		attachments[i] = a
+	}
+	m.Attachments = attachments
+
+	return m, nil
+}
+
 func (m RichMessage) toMsgOptions() []slack.MsgOption {
 	return []slack.MsgOption{
 		slack.MsgOptionText(m.Text, m.EscapeText),
@@ -0,0 +1,146 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ExpediaGroup/flyte-slack/types"
+	"github.com/rs/zerolog/log"
+	"github.com/slack-go/slack"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	getScheduledMessagesLimit = 100 // max 100
+	scheduleMessageTimeout    = 30 * time.Second
+)
+
+func (sl *slackClient) ScheduleMessage(message, channelId, threadTimestamp string, postAt time.Time) (string, error) {
+	unix := strconv.FormatInt(postAt.Unix(), 10)
+	opts := []slack.MsgOption{
+		slack.MsgOptionText(message, false),
+		slack.MsgOptionAsUser(true),
+	}
+	if threadTimestamp != "" {
+		opts = append(opts, slack.MsgOptionTS(threadTimestamp))
+	}
+
+	respChannel, id, err := sl.scheduler.ScheduleMessage(channelId, unix, opts...)
+	if err != nil {
+		return "", fmt.Errorf("cannot schedule message=%q: %v", message, err)
+	}
+
+	log.Info().Msgf("message=%q scheduled to channel=%s at=%s id=%s", message, respChannel, postAt, id)
+	return id, nil
+}
+
+// scheduler schedules messages returning scheduled message id
+type scheduler interface {
+	ScheduleMessage(channelId, postAt string, options ...slack.MsgOption) (respChannel, scheduledMessageId string, err error)
+}
+
+// webScheduler calls chat.scheduleMessage directly, slack client drops scheduled_message_id from the response
+type webScheduler struct {
+	token  string
+	apiUrl string
+	// httpClient has a timeout, so that stalled slack doesn't block the command forever
+	httpClient *http.Client
+}
+
+func newWebScheduler(token, apiUrl string) *webScheduler {
+	return &webScheduler{token: token, apiUrl: apiUrl, httpClient: &http.Client{Timeout: scheduleMessageTimeout}}
+}
+
+type scheduleMessageResponse struct {
+	Ok                 bool   `json:"ok"`
+	Error              string `json:"error"`
+	Channel            string `json:"channel"`
+	ScheduledMessageId string `json:"scheduled_message_id"`
+}
+
+func (w *webScheduler) ScheduleMessage(channelId, postAt string, options ...slack.MsgOption) (string, string, error) {
+	opts := append([]slack.MsgOption{slack.MsgOptionSchedule(postAt)}, options...)
+	endpoint, values, err := slack.UnsafeApplyMsgOptions(w.token, channelId, w.apiUrl, opts...)
+	if err != nil {
+		return "", "", err
+	}
+
+	resp, err := w.httpClient.PostForm(endpoint, values)
+	if err != nil {
+		return "", "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("slack responded with status=%d", resp.StatusCode)
+	}
+
+	out := scheduleMessageResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return "", "", err
+	}
+	if !out.Ok {
+		return "", "", errors.New(out.Error)
+	}
+	return out.Channel, out.ScheduledMessageId, nil
+}
+
+func (sl *slackClient) ListScheduledMessages(channelId string) ([]types.ScheduledMessage, error) {
+	params := &slack.GetScheduledMessagesParameters{
+		Channel: channelId,
+		Limit:   getScheduledMessagesLimit,
+	}
+
+	var out []types.ScheduledMessage
+	for {
+		msgs, cursor, err := sl.client.GetScheduledMessages(params)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range msgs {
+			out = append(out, types.ScheduledMessage{
+				ID:          msgs[i].ID,
+				ChannelID:   msgs[i].Channel,
+				PostAt:      msgs[i].PostAt,
+				DateCreated: msgs[i].DateCreated,
+				Text:        msgs[i].Text,
+			})
+		}
+
+		if cursor == "" {
+			return out, nil
+		}
+		params.Cursor = cursor
+	}
+}
+
+func (sl *slackClient) DeleteScheduledMessage(channelId, scheduledMessageId string) error {
+	_, err := sl.client.DeleteScheduledMessage(&slack.DeleteScheduledMessageParameters{
+		Channel:            channelId,
+		ScheduledMessageID: scheduledMessageId,
+	})
+	if err != nil {
+		return fmt.Errorf("cannot delete scheduled message=%s in channel=%s: %v", scheduledMessageId, channelId, err)
+	}
+
+	log.Info().Msgf("scheduled message=%s deleted from channel=%s", scheduledMessageId, channelId)
+	return nil
+}
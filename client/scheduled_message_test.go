@@ -0,0 +1,121 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"errors"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestScheduleMessageReturnsScheduledMessageId(t *testing.T) {
+	Before(t)
+
+	var gotPostAt string
+	SlackMockClient.ScheduleMessageFunc = func(channelID, postAt string, options ...slack.MsgOption) (string, string, error) {
+		gotPostAt = postAt
+		return channelID, "Q1", nil
+	}
+
+	id, err := SlackImpl.ScheduleMessage("checklist", "C123", "", time.Unix(1792486800, 0))
+	require.NoError(t, err)
+
+	assert.Equal(t, "1792486800", gotPostAt)
+	assert.Equal(t, "Q1", id)
+}
+
+func TestWebSchedulerReadsScheduledMessageId(t *testing.T) {
+	var form url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/chat.scheduleMessage", r.URL.Path)
+		require.NoError(t, r.ParseForm())
+		form = r.PostForm
+		w.Write([]byte(`{"ok": true, "channel": "C123", "scheduled_message_id": "Q1", "post_at": "1792486800"}`))
+	}))
+	defer server.Close()
+	w := newWebScheduler("token", server.URL+"/")
+
+	ch, id, err := w.ScheduleMessage("C123", "1792486800", slack.MsgOptionText("checklist & co", false))
+
+	require.NoError(t, err)
+	assert.Equal(t, "C123", ch)
+	assert.Equal(t, "Q1", id)
+	assert.Equal(t, "1792486800", form.Get("post_at"))
+	assert.Equal(t, "checklist & co", form.Get("text"))
+}
+
+func TestWebSchedulerReturnsSlackError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"ok": false, "error": "time_in_past"}`))
+	}))
+	defer server.Close()
+	w := newWebScheduler("token", server.URL+"/")
+
+	_, _, err := w.ScheduleMessage("C123", "1")
+
+	require.Error(t, err)
+	assert.Equal(t, "time_in_past", err.Error())
+}
+
+func TestWebSchedulerTimesOut(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+	w := newWebScheduler("token", server.URL+"/")
+	w.httpClient.Timeout = 50 * time.Millisecond
+
+	_, _, err := w.ScheduleMessage("C123", "1")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Client.Timeout exceeded")
+}
+
+func TestScheduleMessageShouldReturnErrorOnFailure(t *testing.T) {
+	Before(t)
+
+	SlackMockClient.ScheduleMessageFunc = func(channelID, postAt string, options ...slack.MsgOption) (string, string, error) {
+		return "", "", errors.New("time_in_past")
+	}
+
+	_, err := SlackImpl.ScheduleMessage("checklist", "C123", "", time.Unix(1, 0))
+
+	require.Error(t, err)
+	assert.Equal(t, `cannot schedule message="checklist": time_in_past`, err.Error())
+}
+
+func TestDeleteScheduledMessage(t *testing.T) {
+	Before(t)
+
+	var got *slack.DeleteScheduledMessageParameters
+	SlackMockClient.DeleteScheduledMessageFunc = func(params *slack.DeleteScheduledMessageParameters) (bool, error) {
+		got = params
+		return true, nil
+	}
+
+	require.NoError(t, SlackImpl.DeleteScheduledMessage("C123", "Q1"))
+	assert.Equal(t, "C123", got.Channel)
+	assert.Equal(t, "Q1", got.ScheduledMessageID)
+}
@@ -20,9 +20,15 @@ import (
 	"errors"
 	"fmt"
 	"github.com/ExpediaGroup/flyte-client/flyte"
+	"github.com/ExpediaGroup/flyte-slack/cache"
 	"github.com/ExpediaGroup/flyte-slack/types"
 	"github.com/rs/zerolog/log"
 	"github.com/slack-go/slack"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
 )
 
 type client interface {
@@ -31,38 +37,172 @@ type client interface {
 	SendMessage(message *slack.OutgoingMessage)
 	PostMessage(channel string, opts ...slack.MsgOption) (string, string, error)
 	GetConversations(params *slack.GetConversationsParameters) (channels []slack.Channel, nextCursor string, err error)
+	GetScheduledMessages(params *slack.GetScheduledMessagesParameters) (channels []slack.ScheduledMessage, nextCursor string, err error)
+	DeleteScheduledMessage(params *slack.DeleteScheduledMessageParameters) (bool, error)
+	UploadFile(params slack.FileUploadParameters) (file *slack.File, err error)
+	GetUsers() ([]slack.User, error)
+	GetUserByEmail(email string) (*slack.User, error)
+	GetUserGroups(options ...slack.GetUserGroupsOption) ([]slack.UserGroup, error)
+	GetConversationInfo(channelID string, includeLocale bool) (*slack.Channel, error)
+	GetPermalink(params *slack.PermalinkParameters) (string, error)
+	GetFileInfo(fileID string, count, page int) (*slack.File, []slack.Comment, *slack.Paging, error)
+	GetFile(downloadURL string, writer io.Writer) error
+	AddPin(channel string, item slack.ItemRef) error
+	RemovePin(channel string, item slack.ItemRef) error
+	ListPins(channel string) ([]slack.Item, *slack.Paging, error)
+	GetConversationReplies(params *slack.GetConversationRepliesParameters) (msgs []slack.Message, hasMore bool, nextCursor string, err error)
+	CreateConversation(channelName string, isPrivate bool) (*slack.Channel, error)
+	ArchiveConversation(channelID string) error
+	UnArchiveConversation(channelID string) error
+	RenameConversation(channelID, channelName string) (*slack.Channel, error)
+	InviteUsersToConversation(channelID string, users ...string) (*slack.Channel, error)
+	KickUserFromConversation(channelID string, user string) error
+	GetUserGroupMembers(userGroup string) ([]string, error)
+	SetTopicOfConversation(channelID, topic string) (*slack.Channel, error)
+	SetPurposeOfConversation(channelID, purpose string) (*slack.Channel, error)
+	JoinConversation(channelID string) (*slack.Channel, string, []string, error)
+	LeaveConversation(channelID string) (bool, error)
+	GetUsersInConversation(params *slack.GetUsersInConversationParameters) ([]string, string, error)
+	GetConversationHistory(params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error)
+	UpdateUserGroupMembers(userGroup string, members string) (slack.UserGroup, error)
+	CreateUserGroup(userGroup slack.UserGroup) (slack.UserGroup, error)
+	DisableUserGroup(userGroup string) (slack.UserGroup, error)
+	GetDNDInfo(user *string) (*slack.DNDStatus, error)
 }
 
 // our slack implementation makes consistent use of channel id
 type Slack interface {
-	SendMessage(message, channelId, threadTimestamp string)
+	// SendMessage returns timestamp of the sent message, it's empty when slack doesn't report it for snippets
+	SendMessage(message, channelId, threadTimestamp string) (timestamp string, err error)
 	SendRichMessage(rm RichMessage) (respChannel string, respTimestamp string, err error)
+	GetPermalink(channelId, timestamp string) (string, error)
 	IncomingMessages() <-chan flyte.Event
 	// GetConversations is a heavy call used to fetch data about all channels in a workspace
 	// intended to be cached, not called each time this is needed
 	GetConversations() ([]types.Conversation, error)
+	GetConversationInfo(channelId string) (*types.Conversation, error)
+	// ScheduleMessage queues message to be posted at given time and returns scheduled message id
+	ScheduleMessage(message, channelId, threadTimestamp string, postAt time.Time) (string, error)
+	ListScheduledMessages(channelId string) ([]types.ScheduledMessage, error)
+	DeleteScheduledMessage(channelId, scheduledMessageId string) error
+	UploadFile(f FileUpload) (fileId string, permalink string, err error)
+	// GetUserGroups is a heavy call fetching all user groups in a workspace,
+	// intended to be cached, not called each time this is needed
+	GetUserGroups() ([]types.UserGroup, error)
+	// DownloadFile fetches private file with the bot token, failing for files over maxSize bytes
+	DownloadFile(fileId string, maxSize int) (*DownloadedFile, error)
+	PinMessage(channelId, timestamp string) error
+	UnpinMessage(channelId, timestamp string) error
+	ListPins(channelId string) ([]types.Pin, error)
+	// CreateChannel, ArchiveChannel, UnarchiveChannel and RenameChannel update cache straight away
+	CreateChannel(name string, isPrivate bool, memberIds []string) (conv *types.Conversation, invited []string, failed []UserFailure, err error)
+	ArchiveChannel(channelId string) error
+	UnarchiveChannel(channelId string) error
+	RenameChannel(channelId, name string) (*types.Conversation, error)
+	GetUserGroupMembers(userGroupId string) ([]string, error)
+	// InviteToChannel and RemoveFromChannel report users that failed one by one, error is only returned
+	// when the channel itself is the problem, e.g. it doesn't exist, users handled before that are returned with it
+	InviteToChannel(channelId string, userIds []string) (invited []string, failed []UserFailure, err error)
+	RemoveFromChannel(channelId string, userIds []string) (removed []string, failed []UserFailure, err error)
+	ListChannelMembers(channelId string) ([]string, error)
+	// SetChannelTopic and SetChannelPurpose update cache straight away
+	SetChannelTopic(channelId, topic string) (*types.Conversation, error)
+	SetChannelPurpose(channelId, purpose string) (*types.Conversation, error)
+	// GetUserInfo, LookupUserByEmail and LookupUserByName go through the user cache shared with incoming events
+	GetUserInfo(userId string) (*User, error)
+	LookupUserByEmail(email string) (*User, error)
+	LookupUserByName(name string) (*User, error)
+	// GetUsersInfo lists all users instead of fetching uncached users one by one, users that can't be found are
+	// reported one by one
+	GetUsersInfo(userIds []string) (users []User, failed []UserFailure)
+	JoinChannel(channelId string) (*types.Conversation, error)
+	LeaveChannel(channelId string) error
+	// GetChannelHistory and GetThreadReplies page through messages up to limit, hasMore tells whether
+	// there were more messages than that
+	GetChannelHistory(channelId, oldest, latest string, limit int) (msgs []MessageEvent, hasMore bool, err error)
+	GetThreadReplies(channelId, threadTimestamp string, limit int) (msgs []MessageEvent, hasMore bool, err error)
+	ListUserGroups(includeDisabled bool) ([]types.UserGroup, error)
+	// UpdateUserGroupMembers, CreateUserGroup and DisableUserGroup update cache straight away
+	UpdateUserGroupMembers(userGroupId string, userIds []string) (*types.UserGroup, error)
+	CreateUserGroup(name, handle, description string) (*types.UserGroup, error)
+	DisableUserGroup(userGroupId string) (*types.UserGroup, error)
+	// SetUserStatus needs user token, it fails when one isn't configured
+	SetUserStatus(userId, text, emoji string, expiration time.Time) error
+	GetDndInfo(userId string) (*types.DndStatus, error)
+}
+
+// Config holds optional client settings, zero value keeps slack defaults
+type Config struct {
+	// SnippetThreshold is message length above which SendMessage uploads the message as a text snippet
+	// instead of splitting it into several messages, 0 disables snippets
+	SnippetThreshold int
+	// ThreadContext adds root message of the thread to ReceivedMessage events for thread replies
+	ThreadContext bool
+	// AutoJoinChannels lists public channel names or name patterns such as inc-* the bot joins at startup
+	AutoJoinChannels []string
+	// UserToken is used for calls bot token can't make, e.g. SetUserStatus, empty disables them
+	UserToken string
 }
 
 type slackClient struct {
 	client client
+	// scheduler schedules messages with the bot token
+	scheduler scheduler
+	// userClient uses the user token, it's nil when one isn't configured
+	userClient userClient
+	cfg        *Config
+	cache      cache.Cache
 	// events received from slack
 	incomingEvents chan slack.RTMEvent
+	// events left after acks are routed to senders, consumed by the incoming events handler
+	events chan slack.RTMEvent
 	// messages to be consumed by API (filtered incoming events)
 	incomingMessages chan flyte.Event
+	// botUserId is set once connected, it's only accessed by the incoming events handler
+	botUserId string
+	// botMention matches mentions of the bot user, it's compiled once connected and only accessed by the incoming
+	// events handler
+	botMention *regexp.Regexp
+	// workspaceUrl is set once connected, it's used for permalinks by both command handlers and incoming events
+	workspaceUrl   string
+	workspaceUrlMu sync.Mutex
+	// threadRoots caches thread root messages by channel and thread timestamp, only accessed by the incoming events handler
+	threadRoots map[string]*threadRoot
+	// users caches users by id for both command handlers and incoming events
+	users *userCache
+	// acks passes acknowledgements of messages sent over RTM to SendMessage
+	acks       *acks
+	ackTimeout time.Duration
 }
 
-func NewSlack(token string) Slack {
+func NewSlack(token string, cfg *Config, cache cache.Cache) Slack {
 
 	rtm := slack.New(token).NewRTM()
 	go rtm.ManageConnection()
 
 	sl := &slackClient{
 		client:           rtm,
+		scheduler:        newWebScheduler(token, slack.APIURL),
+		cfg:              cfg,
+		cache:            cache,
 		incomingEvents:   rtm.IncomingEvents,
+		events:           make(chan slack.RTMEvent),
 		incomingMessages: make(chan flyte.Event),
+		threadRoots:      make(map[string]*threadRoot),
+		users:            newUserCache(),
+		acks:             newAcks(),
+		ackTimeout:       defaultAckTimeout,
+	}
+	if cfg.UserToken != "" {
+		sl.userClient = slack.New(cfg.UserToken)
+	}
+
+	if len(cfg.AutoJoinChannels) != 0 {
+		sl.autoJoin(cfg.AutoJoinChannels)
 	}
 
 	log.Info().Msg("initialized slack")
+	go sl.routeEvents()
 	go sl.handleMessageEvents()
 	return sl
 }
@@ -85,11 +225,7 @@ func (sl *slackClient) GetConversations() ([]types.Conversation, error) {
 
 	out := make([]types.Conversation, 0, len(chans))
 	for i := range chans {
-		out = append(out, types.Conversation{
-			ID:    chans[i].ID,
-			Name:  chans[i].Name,
-			Topic: chans[i].Topic.Value,
-		})
+		out = append(out, toConversation(&chans[i]))
 	}
 
 	for cursor != "" {
@@ -101,25 +237,126 @@ func (sl *slackClient) GetConversations() ([]types.Conversation, error) {
 		}
 
 		for i := range chans {
-			out = append(out, types.Conversation{
-				ID:    chans[i].ID,
-				Name:  chans[i].Name,
-				Topic: chans[i].Topic.Value,
-			})
+			out = append(out, toConversation(&chans[i]))
 		}
 	}
 
 	return out, nil
 }
 
-// Sends slack message to provided channel. Channel does not have to be joined.
-func (sl *slackClient) SendMessage(message, channelId, threadTimestamp string) {
+func (sl *slackClient) GetConversationInfo(channelId string) (*types.Conversation, error) {
+	ch, err := sl.client.GetConversationInfo(channelId, false)
+	if err != nil {
+		return nil, err
+	}
+
+	out := toConversation(ch)
+	return &out, nil
+}
+
+func toConversation(ch *slack.Channel) types.Conversation {
+	return types.Conversation{
+		ID:         ch.ID,
+		Name:       ch.Name,
+		Topic:      ch.Topic.Value,
+		Purpose:    ch.Purpose.Value,
+		Type:       conversationType(ch),
+		IsArchived: ch.IsArchived,
+	}
+}
+
+func conversationType(ch *slack.Channel) string {
+	switch {
+	case ch.IsIM:
+		return "im"
+	case ch.IsMpIM:
+		return "mpim"
+	case ch.IsPrivate || ch.IsGroup:
+		return "group"
+	default:
+		return "channel"
+	}
+}
+
+// Sends slack message over RTM to provided channel and returns its timestamp taken from slack's ack, message that
+// isn't acknowledged in time is reported as unconfirmed error. Channel does not have to be joined.
+// Messages over slack's length limit are split and posted through the web API, the rest of the chunks are sent
+// as thread replies and timestamp of the first chunk is returned then.
+func (sl *slackClient) SendMessage(message, channelId, threadTimestamp string) (string, error) {
+
+	if sl.cfg.SnippetThreshold > 0 && len(message) > sl.cfg.SnippetThreshold {
+		return sl.sendSnippet(message, channelId, threadTimestamp)
+	}
+
+	if chunks := splitMessage(message, maxMessageLength); len(chunks) > 1 {
+		return sl.sendChunks(chunks, channelId, threadTimestamp)
+	}
 
 	msg := sl.client.NewOutgoingMessage(message, channelId)
 	msg.ThreadTimestamp = threadTimestamp
+	ack := sl.acks.expect(msg.ID)
 	sl.client.SendMessage(msg)
-	log.Info().Msgf("message=%q sent to channel=%s", msg.Text, channelId)
+	ts, err := sl.acks.wait(msg.ID, ack, sl.ackTimeout)
+	if _, ok := err.(*errNoAck); ok {
+		return "", fmt.Errorf("cannot confirm message was sent to channel=%s: %v", channelId, err)
+	}
+	if err != nil {
+		return "", fmt.Errorf("cannot send message to channel=%s: %v", channelId, err)
+	}
+	log.Info().Msgf("message=%q sent to channel=%s", message, channelId)
+	return ts, nil
+}
+
+// postText posts plain text through the web API, it's used for chunks as each of them needs timestamp of the first
+func (sl *slackClient) postText(text, channelId, threadTimestamp string) (string, error) {
+	opts := []slack.MsgOption{slack.MsgOptionText(text, false), slack.MsgOptionAsUser(true)}
+	if threadTimestamp != "" {
+		opts = append(opts, slack.MsgOptionTS(threadTimestamp))
+	}
 
+	_, ts, err := sl.client.PostMessage(channelId, opts...)
+	return ts, err
+}
+
+// sendChunks posts the first chunk and replies with the rest in its thread
+func (sl *slackClient) sendChunks(chunks []string, channelId, threadTimestamp string) (string, error) {
+	first := ""
+	for i, chunk := range chunks {
+		ts, err := sl.postText(chunk, channelId, threadTimestamp)
+		if err != nil {
+			return first, fmt.Errorf("cannot send chunk %d/%d of message to channel=%s: %v", i+1, len(chunks), channelId, err)
+		}
+		if first == "" {
+			first = ts
+		}
+		if threadTimestamp == "" {
+			threadTimestamp = ts
+		}
+	}
+	log.Info().Msgf("message split into %d chunks sent to channel=%s thread=%s", len(chunks), channelId, threadTimestamp)
+	return first, nil
+}
+
+// sendSnippet uploads message as a text snippet, timestamp of the message sharing it is returned when slack reports it
+func (sl *slackClient) sendSnippet(message, channelId, threadTimestamp string) (string, error) {
+	file, err := sl.client.UploadFile(slack.FileUploadParameters{
+		Content:         message,
+		Filetype:        "text",
+		Filename:        "message.txt",
+		Channels:        []string{channelId},
+		ThreadTimestamp: threadTimestamp,
+	})
+	if err != nil {
+		return "", fmt.Errorf("cannot send message as snippet to channel=%s: %v", channelId, err)
+	}
+	log.Info().Msgf("message sent as snippet=%s to channel=%s", file.ID, channelId)
+
+	for _, shares := range []map[string][]slack.ShareFileInfo{file.Shares.Public, file.Shares.Private} {
+		if s := shares[channelId]; len(s) != 0 {
+			return s[0].Ts, nil
+		}
+	}
+	return "", nil
 }
 
 func (sl *slackClient) SendRichMessage(rm RichMessage) (string, string, error) {
@@ -136,26 +373,157 @@ func (sl *slackClient) IncomingMessages() <-chan flyte.Event {
 	return sl.incomingMessages
 }
 
+// routeEvents hands acks to senders straight away and queues other events for the incoming events handler, so that
+// acks don't wait behind events that need users or channels to be looked up
+func (sl *slackClient) routeEvents() {
+	var queue []slack.RTMEvent
+	for {
+		var out chan slack.RTMEvent
+		var next slack.RTMEvent
+		if len(queue) != 0 {
+			out = sl.events
+			next = queue[0]
+		}
+
+		select {
+		case event := <-sl.incomingEvents:
+			if !sl.acks.handle(event) {
+				queue = append(queue, event)
+			}
+		case out <- next:
+			queue = queue[1:]
+		}
+	}
+}
+
 func (sl *slackClient) handleMessageEvents() {
-	for event := range sl.incomingEvents {
+	for event := range sl.events {
 		switch v := event.Data.(type) {
 		case *slack.MessageEvent:
+			v = editedMessage(v)
+			if v == nil {
+				continue
+			}
 			log.Debug().Msgf("received message=%s in channel=%s", v.Text, v.Channel)
-			u, err := sl.client.GetUserInfo(v.User)
+			u, err := sl.messageAuthor(v)
+			if err != nil {
+				log.Err(err).Msgf("cannot get info about user=%s", v.User)
+				continue
+			}
+			permalink := sl.messagePermalink(v.Channel, v.Timestamp, v.ThreadTimestamp)
+			msg := newMessageEvent(v, u, sl.conversation(v.Channel), permalink, sl.parseMarkup(v.Text))
+			msg.ThreadRoot = sl.threadRoot(v)
+			sl.incomingMessages <- toFlyteMessageEvent(msg)
+			// edits and messages of bots without user are only sent as ReceivedMessage
+			if msg.Edited || v.User == "" {
+				continue
+			}
+			if sl.isDirectMessage(msg) {
+				sl.incomingMessages <- toFlyteDirectMessageEvent(msg)
+			}
+			if sl.mentionsBot(v) {
+				sl.incomingMessages <- toFlyteAppMentionedEvent(msg, sl.botMention)
+			}
+
+		case *slack.MemberJoinedChannelEvent:
+			log.Debug().Msgf("user=%s joined channel=%s", v.User, v.Channel)
+			u, err := sl.userInfo(v.User)
+			if err != nil {
+				log.Err(err).Msgf("cannot get info about user=%s", v.User)
+				continue
+			}
+			sl.incomingMessages <- toFlyteMemberJoinedChannelEvent(u, sl.inviter(v.Inviter), sl.conversation(v.Channel))
+
+		case *slack.MemberLeftChannelEvent:
+			log.Debug().Msgf("user=%s left channel=%s", v.User, v.Channel)
+			u, err := sl.userInfo(v.User)
+			if err != nil {
+				log.Err(err).Msgf("cannot get info about user=%s", v.User)
+				continue
+			}
+			sl.incomingMessages <- toFlyteMemberLeftChannelEvent(u, sl.conversation(v.Channel))
+
+		case *slack.ChannelCreatedEvent:
+			sl.incomingMessages <- sl.channelLifecycleEvent(channelCreatedEventName, v.Channel.ID, v.Channel.Name, v.Channel.Creator)
+		case *slack.GroupCreatedEvent:
+			sl.incomingMessages <- sl.channelLifecycleEvent(channelCreatedEventName, v.Channel.ID, v.Channel.Name, v.User)
+		case *slack.ChannelRenameEvent:
+			sl.incomingMessages <- sl.channelLifecycleEvent(channelRenamedEventName, v.Channel.ID, v.Channel.Name, "")
+		case *slack.GroupRenameEvent:
+			sl.incomingMessages <- sl.channelLifecycleEvent(channelRenamedEventName, v.Group.ID, v.Group.Name, "")
+		case *slack.ChannelArchiveEvent:
+			sl.incomingMessages <- sl.channelLifecycleEvent(channelArchivedEventName, v.Channel, "", v.User)
+		case *slack.GroupArchiveEvent:
+			sl.incomingMessages <- sl.channelLifecycleEvent(channelArchivedEventName, v.Channel, "", v.User)
+		case *slack.ChannelUnarchiveEvent:
+			sl.incomingMessages <- sl.channelLifecycleEvent(channelUnarchivedEventName, v.Channel, "", v.User)
+		case *slack.GroupUnarchiveEvent:
+			sl.incomingMessages <- sl.channelLifecycleEvent(channelUnarchivedEventName, v.Channel, "", v.User)
+
+		case *slack.TeamJoinEvent:
+			log.Debug().Msgf("user=%s joined workspace", v.User.ID)
+			sl.users.set(&v.User)
+			sl.incomingMessages <- toFlyteUserJoinedWorkspaceEvent(&v.User)
+
+		case *slack.UserChangeEvent:
+			changes, known := sl.profileChanges(&v.User)
+			sl.users.set(&v.User)
+			if known && len(changes) == 0 {
+				log.Debug().Msgf("user=%s changed, no profile fields differ", v.User.ID)
+				continue
+			}
+			sl.incomingMessages <- toFlyteUserChangedEvent(&v.User, changes)
+
+		case *slack.PinAddedEvent:
+			log.Debug().Msgf("user=%s pinned item in channel=%s", v.User, v.Channel)
+			u, err := sl.userInfo(v.User)
 			if err != nil {
 				log.Err(err).Msgf("cannot get info about user=%s", v.User)
 				continue
 			}
-			sl.incomingMessages <- toFlyteMessageEvent(v, u)
+			sl.incomingMessages <- toFlytePinEvent("PinAdded", v.Channel, u, v.Item, sl.conversation(v.Channel))
+
+		case *slack.PinRemovedEvent:
+			log.Debug().Msgf("user=%s unpinned item in channel=%s", v.User, v.Channel)
+			u, err := sl.userInfo(v.User)
+			if err != nil {
+				log.Err(err).Msgf("cannot get info about user=%s", v.User)
+				continue
+			}
+			sl.incomingMessages <- toFlytePinEvent("PinRemoved", v.Channel, u, v.Item, sl.conversation(v.Channel))
+
+		case *slack.StarAddedEvent:
+			log.Debug().Msgf("user=%s starred item in channel=%s", v.User, v.Item.Channel)
+			sl.emitStarEvent("StarAdded", v.User, slack.Item(v.Item))
+
+		case *slack.StarRemovedEvent:
+			log.Debug().Msgf("user=%s unstarred item in channel=%s", v.User, v.Item.Channel)
+			sl.emitStarEvent("StarRemoved", v.User, slack.Item(v.Item))
+
+		case *slack.SubteamUpdatedEvent:
+			log.Debug().Msgf("user group=%s updated", v.Subteam.ID)
+			sl.incomingMessages <- sl.toFlyteUserGroupUpdatedEvent(&v.Subteam)
+
+		case *slack.ConnectedEvent:
+			if v.Info != nil && v.Info.User != nil {
+				log.Info().Msgf("connected to slack as user=%s", v.Info.User.ID)
+				sl.botUserId = v.Info.User.ID
+				sl.botMention = newBotMentionRegexp(v.Info.User.ID)
+			}
+			if v.Info != nil && v.Info.Team != nil && v.Info.Team.Domain != "" {
+				sl.workspaceUrlMu.Lock()
+				sl.workspaceUrl = fmt.Sprintf("https://%s.slack.com/", v.Info.Team.Domain)
+				sl.workspaceUrlMu.Unlock()
+			}
 
 		case *slack.ReactionAddedEvent:
 			log.Debug().Msgf("received reaction event payload = %v", v)
-			u, err := sl.client.GetUserInfo(v.User)
+			u, err := sl.userInfo(v.User)
 			if err != nil {
 				log.Err(err).Msgf("cannot get info about user=%s: %v", v.User, err)
 				continue
 			}
-			i, err := sl.client.GetUserInfo(v.ItemUser)
+			i, err := sl.userInfo(v.ItemUser)
 			if err != nil {
 				log.Err(err).Msgf("cannot get info about item user=%v: %v", v.ItemUser, err)
 				continue
@@ -165,34 +533,143 @@ func (sl *slackClient) handleMessageEvents() {
 	}
 }
 
-func toFlyteMessageEvent(event *slack.MessageEvent, user *slack.User) flyte.Event {
+// emitStarEvent sends StarAdded or StarRemoved event, channel is only looked up for items starred in one
+func (sl *slackClient) emitStarEvent(name, userId string, item slack.Item) {
+	u, err := sl.userInfo(userId)
+	if err != nil {
+		log.Err(err).Msgf("cannot get info about user=%s", userId)
+		return
+	}
+	conv := types.Conversation{}
+	if item.Channel != "" {
+		conv = sl.conversation(item.Channel)
+	}
+	sl.incomingMessages <- toFlyteStarEvent(name, u, item, conv)
+}
+
+// conversation returns cached channel details, falling back to just the id when they can't be fetched
+func (sl *slackClient) conversation(channelId string) types.Conversation {
+	c, err := sl.cache.GetConversation(channelId, sl)
+	if err != nil {
+		log.Err(err).Msgf("cannot get info about channel=%s", channelId)
+		return types.Conversation{ID: channelId}
+	}
+	return *c
+}
+
+func (sl *slackClient) GetPermalink(channelId, timestamp string) (string, error) {
+	p, err := sl.client.GetPermalink(&slack.PermalinkParameters{Channel: channelId, Ts: timestamp})
+	if err != nil {
+		return "", fmt.Errorf("cannot get permalink of message=%s in channel=%s: %v", timestamp, channelId, err)
+	}
+	return p, nil
+}
+
+// messagePermalink builds link to the message from the workspace url, so incoming messages don't need
+// chat.getPermalink calls, it's empty until connected
+func (sl *slackClient) messagePermalink(channelId, timestamp, threadTimestamp string) string {
+	sl.workspaceUrlMu.Lock()
+	workspaceUrl := sl.workspaceUrl
+	sl.workspaceUrlMu.Unlock()
+	if workspaceUrl == "" {
+		return ""
+	}
+	p := fmt.Sprintf("%sarchives/%s/p%s", workspaceUrl, channelId, strings.Replace(timestamp, ".", "", 1))
+	if threadTimestamp != "" && threadTimestamp != timestamp {
+		p += fmt.Sprintf("?thread_ts=%s&cid=%s", threadTimestamp, channelId)
+	}
+	return p
+}
+
+// editedMessage turns message_changed event into the edited message, it's nil for changes that aren't edits,
+// e.g. unfurled links or new thread replies. Other messages are returned as they are.
+func editedMessage(e *slack.MessageEvent) *slack.MessageEvent {
+	if e.SubType != "message_changed" {
+		return e
+	}
+	if e.SubMessage == nil || e.SubMessage.Edited == nil {
+		return nil
+	}
+	out := &slack.MessageEvent{Msg: *e.SubMessage}
+	out.Channel = e.Channel
+	out.SubType = e.SubType
+	return out
+}
+
+// messageAuthor gets user that sent the message, messages of bots without user are attributed to the bot name
+func (sl *slackClient) messageAuthor(e *slack.MessageEvent) (*slack.User, error) {
+	if e.User == "" && e.BotID != "" {
+		name := e.Username
+		if name == "" && e.BotProfile != nil {
+			name = e.BotProfile.Name
+		}
+		return &slack.User{Name: name, IsBot: true}, nil
+	}
+	return sl.userInfo(e.User)
+}
+
+func toFlyteMessageEvent(msg MessageEvent) flyte.Event {
 
 	return flyte.Event{
 		EventDef: flyte.EventDef{Name: "ReceivedMessage"},
-		Payload:  newMessageEvent(event, user),
+		Payload:  msg,
 	}
 }
 
-type messageEvent struct {
+// MessageEvent is message as sent in ReceivedMessage events and returned by message history commands
+type MessageEvent struct {
 	ChannelId       string        `json:"channelId"`
-	User            user          `json:"user"`
+	ChannelName     string        `json:"channelName"`
+	ChannelType     string        `json:"channelType"`
+	User            User          `json:"user"`
 	Message         string        `json:"message"`
+	Subtype         string        `json:"subtype"`
+	BotId           string        `json:"botId"`
+	Edited          bool          `json:"edited"`
 	Timestamp       string        `json:"timestamp"`
 	ThreadTimestamp string        `json:"threadTimestamp"`
+	IsThreadReply   bool          `json:"isThreadReply"`
+	ParentUserId    string        `json:"parentUserId"`
 	ReplyCount      int           `json:"replyCount"`
 	Replies         []slack.Reply `json:"replies"`
+	Permalink       string        `json:"permalink"`
+	messageMarkup
+	Files       []file             `json:"files"`
+	Attachments []slack.Attachment `json:"attachments"`
+	Blocks      slack.Blocks       `json:"blocks"`
+	// ThreadRoot is only set for thread replies when thread context is enabled
+	ThreadRoot *threadRoot `json:"threadRoot"`
 }
 
-func newMessageEvent(e *slack.MessageEvent, u *slack.User) messageEvent {
-	return messageEvent{
+func newMessageEvent(e *slack.MessageEvent, u *slack.User, conv types.Conversation, permalink string, markup messageMarkup) MessageEvent {
+	out := MessageEvent{
 		ChannelId:       e.Channel,
+		ChannelName:     conv.Name,
+		ChannelType:     conv.Type,
 		User:            newUser(u),
 		Message:         e.Text,
+		Subtype:         e.SubType,
+		BotId:           e.BotID,
+		Edited:          e.Edited != nil,
 		Timestamp:       e.Timestamp,
 		ThreadTimestamp: getThreadTimestamp(e),
+		IsThreadReply:   e.ThreadTimestamp != "" && e.ThreadTimestamp != e.Timestamp,
+		ParentUserId:    e.ParentUserId,
 		ReplyCount:      e.ReplyCount,
 		Replies:         e.Replies,
+		Permalink:       permalink,
+		messageMarkup:   markup,
+		Files:           newFiles(e.Files),
+		Attachments:     e.Attachments,
+		Blocks:          e.Blocks,
 	}
+	if out.Attachments == nil {
+		out.Attachments = []slack.Attachment{}
+	}
+	if out.Blocks.BlockSet == nil {
+		out.Blocks.BlockSet = []slack.Block{}
+	}
+	return out
 }
 
 func getThreadTimestamp(e *slack.MessageEvent) string {
@@ -202,25 +679,64 @@ func getThreadTimestamp(e *slack.MessageEvent) string {
 	return e.Timestamp
 }
 
-type user struct {
-	Id        string `json:"id"`
-	Name      string `json:"name"`
-	Email     string `json:"email"`
-	Title     string `json:"title"`
-	FirstName string `json:"firstName"`
-	LastName  string `json:"lastName"`
+// User is slack user as sent in event payloads and returned by user commands
+type User struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	Email       string `json:"email"`
+	Title       string `json:"title"`
+	FirstName   string `json:"firstName"`
+	LastName    string `json:"lastName"`
+	Timezone    string `json:"timezone"`
+	StatusText  string `json:"statusText"`
+	StatusEmoji string `json:"statusEmoji"`
+	IsBot       bool   `json:"isBot"`
+	IsAdmin     bool   `json:"isAdmin"`
+	Deleted     bool   `json:"deleted"`
+	AvatarUrl   string `json:"avatarUrl"`
 }
 
-func newUser(u *slack.User) user {
+func newUser(u *slack.User) User {
+
+	return User{
+		Id:          u.ID,
+		Name:        u.Name,
+		Email:       u.Profile.Email,
+		Title:       u.Profile.Title,
+		FirstName:   u.Profile.FirstName,
+		LastName:    u.Profile.LastName,
+		Timezone:    u.TZ,
+		StatusText:  u.Profile.StatusText,
+		StatusEmoji: u.Profile.StatusEmoji,
+		IsBot:       u.IsBot,
+		IsAdmin:     u.IsAdmin,
+		Deleted:     u.Deleted,
+		AvatarUrl:   u.Profile.Image192,
+	}
+}
 
-	return user{
-		Id:        u.ID,
-		Name:      u.Name,
-		Email:     u.Profile.Email,
-		Title:     u.Profile.Title,
-		FirstName: u.Profile.FirstName,
-		LastName:  u.Profile.LastName,
+type file struct {
+	Id         string `json:"id"`
+	Name       string `json:"name"`
+	Mimetype   string `json:"mimetype"`
+	Size       int    `json:"size"`
+	UrlPrivate string `json:"urlPrivate"`
+	Permalink  string `json:"permalink"`
+}
+
+func newFiles(files []slack.File) []file {
+	out := make([]file, 0, len(files))
+	for _, f := range files {
+		out = append(out, file{
+			Id:         f.ID,
+			Name:       f.Name,
+			Mimetype:   f.Mimetype,
+			Size:       f.Size,
+			UrlPrivate: f.URLPrivate,
+			Permalink:  f.Permalink,
+		})
 	}
+	return out
 }
 
 func newReactionEvent(e *slack.ReactionAddedEvent, user, itemUser *slack.User) reactionEvent {
@@ -246,8 +762,8 @@ type reactionItem struct {
 
 type reactionEvent struct {
 	Type           string       `json:"type"`
-	User           user         `json:"user"`
-	ItemUser       user         `json:"itemUser"`
+	User           User         `json:"user"`
+	ItemUser       User         `json:"itemUser"`
 	Item           reactionItem `json:"item"`
 	Reaction       string       `json:"reaction"`
 	EventTimestamp string       `json:"eventTimestamp"`
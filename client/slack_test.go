@@ -21,9 +21,11 @@ import (
 	"errors"
 	"fmt"
 	"github.com/ExpediaGroup/flyte-client/flyte"
+	"github.com/ExpediaGroup/flyte-slack/cache"
 	"github.com/slack-go/slack"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"io"
 	"strings"
 	"testing"
 	"time"
@@ -33,9 +35,11 @@ var SlackImpl Slack
 var SlackMockClient *MockClient
 
 func Before(t *testing.T) {
-	SlackImpl = NewSlack("token")
+	SlackImpl = NewSlack("token", &Config{}, cache.New(&cache.Config{RenewConversationListFrequency: time.Hour}))
 	SlackMockClient = NewMockClient(t)
 	SlackImpl.(*slackClient).client = SlackMockClient
+	SlackImpl.(*slackClient).scheduler = SlackMockClient
+	SlackImpl.(*slackClient).ackTimeout = 50 * time.Millisecond
 }
 
 func TestSendMessage(t *testing.T) {
@@ -45,7 +49,61 @@ func TestSendMessage(t *testing.T) {
 
 	require.Equal(t, 1, len(SlackMockClient.OutgoingMessages))
 	assert.Equal(t, "the message", SlackMockClient.OutgoingMessages["channel id"][0].Text)
+	assert.Equal(t, "now", SlackMockClient.OutgoingMessages["channel id"][0].ThreadTimestamp)
+}
+
+func TestSendMessageReturnsTimestampFromAck(t *testing.T) {
+	Before(t)
+	SlackImpl.(*slackClient).ackTimeout = time.Second
+	SlackMockClient.SendMessageFunc = func(msg *slack.OutgoingMessage) {
+		SlackImpl.(*slackClient).incomingEvents <- slack.RTMEvent{Type: "ack", Data: &slack.AckMessage{ReplyTo: msg.ID, Timestamp: "1234.5678"}}
+	}
+
+	ts, err := SlackImpl.SendMessage("the message", "channel id", "")
+
+	require.NoError(t, err)
+	assert.Equal(t, "1234.5678", ts)
+}
+
+func TestSendMessageWithoutAckIsUnconfirmed(t *testing.T) {
+	Before(t)
+
+	_, err := SlackImpl.SendMessage("the message", "channel id", "")
+
+	require.Error(t, err)
+	assert.Equal(t, "cannot confirm message was sent to channel=channel id: no ack received in 50ms, message may not have been sent", err.Error())
+}
+
+func TestAckIsNotQueuedBehindOtherEvents(t *testing.T) {
+	Before(t)
+	SlackImpl.(*slackClient).ackTimeout = time.Second
+	SlackMockClient.SendMessageFunc = func(msg *slack.OutgoingMessage) {
+		// nobody consumes incoming messages, so the message event blocks the incoming events handler
+		SlackImpl.(*slackClient).incomingEvents <- slack.RTMEvent{Type: "message", Data: &slack.MessageEvent{Msg: slack.Msg{Channel: "C1", BotID: "B1", Text: "a"}}}
+		SlackImpl.(*slackClient).incomingEvents <- slack.RTMEvent{Type: "message", Data: &slack.MessageEvent{Msg: slack.Msg{Channel: "C1", BotID: "B1", Text: "b"}}}
+		SlackImpl.(*slackClient).incomingEvents <- slack.RTMEvent{Type: "ack", Data: &slack.AckMessage{ReplyTo: msg.ID, Timestamp: "1234.5678"}}
+	}
+
+	ts, err := SlackImpl.SendMessage("the message", "channel id", "")
 
+	require.NoError(t, err)
+	assert.Equal(t, "1234.5678", ts)
+	assert.Equal(t, "a", nextEvent(t).Payload.(MessageEvent).Message)
+	assert.Equal(t, "b", nextEvent(t).Payload.(MessageEvent).Message)
+}
+
+func TestSendMessageFails(t *testing.T) {
+	Before(t)
+	SlackImpl.(*slackClient).ackTimeout = time.Second
+	SlackMockClient.SendMessageFunc = func(msg *slack.OutgoingMessage) {
+		SlackImpl.(*slackClient).incomingEvents <- slack.RTMEvent{Type: "ack_error", Data: &slack.AckErrorEvent{
+			ErrorObj: &slack.RTMError{Code: 2, Msg: "channel_not_found"}, ReplyTo: msg.ID}}
+	}
+
+	_, err := SlackImpl.SendMessage("the message", "channel id", "")
+
+	require.Error(t, err)
+	assert.Equal(t, "cannot send message to channel=channel id: Code 2 - channel_not_found", err.Error())
 }
 
 func TestSendRichMessage(t *testing.T) {
@@ -126,7 +184,7 @@ func TestIncomingMessages(t *testing.T) {
 	select {
 	case msg := <-incomingMessages:
 		assert.Equal(t, "ReceivedMessage", msg.EventDef.Name)
-		payload := msg.Payload.(messageEvent)
+		payload := msg.Payload.(MessageEvent)
 		assert.Equal(t, "id-abc", payload.ChannelId)
 		assert.Equal(t, "hello there ...", payload.Message)
 		assert.Equal(t, "user-id-123", payload.User.Id)
@@ -174,7 +232,7 @@ func TestIncomingMessages(t *testing.T) {
 			select {
 			case msg := <-incomingMessages:
 				assert.Equal(t, "ReceivedMessage", msg.EventDef.Name)
-				payload := msg.Payload.(messageEvent)
+				payload := msg.Payload.(MessageEvent)
 				assert.Equal(t, test.expectedThreadTimestamp, payload.ThreadTimestamp)
 			default:
 				assert.Fail(t, "expected message event")
@@ -184,6 +242,100 @@ func TestIncomingMessages(t *testing.T) {
 	}
 }
 
+func TestIncomingMessageIsEnrichedWithChannelAndPermalink(t *testing.T) {
+	Before(t)
+	SlackMockClient.AddMockGetUserInfoCall("user-id-123", &slack.User{ID: "user-id-123"}, nil)
+	SlackMockClient.GetConversationInfoFunc = func(channelID string, includeLocale bool) (*slack.Channel, error) {
+		ch := &slack.Channel{}
+		ch.ID = channelID
+		ch.Name = "incidents"
+		ch.IsPrivate = true
+		return ch, nil
+	}
+	SlackMockClient.GetPermalinkFunc = func(params *slack.PermalinkParameters) (string, error) {
+		assert.Fail(t, "permalink shouldn't be fetched for incoming messages")
+		return "", nil
+	}
+
+	events := SlackImpl.(*slackClient).incomingEvents
+	events <- slack.RTMEvent{Type: "connected", Data: &slack.ConnectedEvent{Info: &slack.Info{Team: &slack.Team{Domain: "example"}}}}
+	events <- slack.RTMEvent{Type: "message", Data: &slack.MessageEvent{
+		Msg: slack.Msg{
+			Channel:         "G123",
+			User:            "user-id-123",
+			Text:            "reply",
+			Timestamp:       "1600000002.000200",
+			ThreadTimestamp: "1600000001.000100",
+			ParentUserId:    "U-parent",
+			SubType:         "thread_broadcast",
+		},
+	}}
+
+	payload := nextEvent(t).Payload.(MessageEvent)
+	assert.Equal(t, "G123", payload.ChannelId)
+	assert.Equal(t, "incidents", payload.ChannelName)
+	assert.Equal(t, "group", payload.ChannelType)
+	assert.Equal(t, "thread_broadcast", payload.Subtype)
+	assert.True(t, payload.IsThreadReply)
+	assert.Equal(t, "U-parent", payload.ParentUserId)
+	assert.Equal(t, "https://example.slack.com/archives/G123/p1600000002000200?thread_ts=1600000001.000100&cid=G123", payload.Permalink)
+}
+
+func TestEditedMessageIsSentAsEdited(t *testing.T) {
+	Before(t)
+	SlackMockClient.AddMockGetUserInfoCall("user-id-123", &slack.User{ID: "user-id-123"}, nil)
+
+	// message_changed has no user at the top level, the edited message comes in message field
+	SlackImpl.(*slackClient).incomingEvents <- slack.RTMEvent{Type: "message", Data: &slack.MessageEvent{
+		Msg: slack.Msg{Channel: "C123", SubType: "message_changed", Hidden: true, Timestamp: "1600000003.000300"},
+		SubMessage: &slack.Msg{
+			User:      "user-id-123",
+			Text:      "fixed typo",
+			Timestamp: "1600000002.000200",
+			Edited:    &slack.Edited{User: "user-id-123", Timestamp: "1600000003.000000"},
+		},
+		PreviousMessage: &slack.Msg{User: "user-id-123", Text: "fixed tpyo", Timestamp: "1600000002.000200"},
+	}}
+
+	payload := nextEvent(t).Payload.(MessageEvent)
+	assert.Equal(t, "C123", payload.ChannelId)
+	assert.Equal(t, "user-id-123", payload.User.Id)
+	assert.Equal(t, "fixed typo", payload.Message)
+	assert.Equal(t, "message_changed", payload.Subtype)
+	assert.Equal(t, "1600000002.000200", payload.Timestamp)
+	assert.True(t, payload.Edited)
+}
+
+func TestMessageChangedWithoutEditIsIgnored(t *testing.T) {
+	Before(t)
+
+	// e.g. link unfurled
+	SlackImpl.(*slackClient).incomingEvents <- slack.RTMEvent{Type: "message", Data: &slack.MessageEvent{
+		Msg:        slack.Msg{Channel: "C123", SubType: "message_changed", Hidden: true},
+		SubMessage: &slack.Msg{User: "user-id-123", Text: "see https://example.com", Timestamp: "1600000002.000200"},
+	}}
+
+	select {
+	case e := <-SlackImpl.IncomingMessages():
+		assert.Fail(t, "unexpected event", e.EventDef.Name)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestBotMessageWithoutUser(t *testing.T) {
+	Before(t)
+
+	SlackImpl.(*slackClient).incomingEvents <- slack.RTMEvent{Type: "message", Data: &slack.MessageEvent{
+		Msg: slack.Msg{Channel: "C123", SubType: "bot_message", BotID: "B123", Username: "deploy-bot", Text: "deployed", Timestamp: "1600000002.000200"},
+	}}
+
+	payload := nextEvent(t).Payload.(MessageEvent)
+	assert.Equal(t, "bot_message", payload.Subtype)
+	assert.Equal(t, "B123", payload.BotId)
+	assert.Equal(t, "deploy-bot", payload.User.Name)
+	assert.True(t, payload.User.IsBot)
+}
+
 func TestReactionEvents(t *testing.T) {
 	// Given
 	Before(t)
@@ -210,7 +362,7 @@ func TestReactionEvents(t *testing.T) {
 	incomingMessages := SlackImpl.IncomingMessages()
 	select {
 		case msg := <-incomingMessages:
-			u := user{
+			u := User{
 				Id:        "u-foo",
 				Name:      "kfoox",
 				Email:     "k@example.com",
@@ -257,9 +409,9 @@ func sendSlackMessage(slackImpl Slack, text, channel, userId, timestamp, threadT
 		},
 	}
 
-	messageEvent := slack.RTMEvent{Type: "message", Data: data}
+	rtmEvent := slack.RTMEvent{Type: "message", Data: data}
 
-	slackImpl.(*slackClient).incomingEvents <- messageEvent
+	slackImpl.(*slackClient).incomingEvents <- rtmEvent
 }
 
 func newReactionAddedEvent(userId, itemType, channel, itemTs, reaction, ts string) (slack.ReactionAddedEvent, error) {
@@ -288,8 +440,44 @@ type MockClient struct {
 	GetUserInfoFns []func(userId string) (*slack.User, error)
 	// map stores all the sent messages by channelId (key is channelId)
 	OutgoingMessages map[string][]*slack.OutgoingMessage
+	// SendMessageFunc is called with each sent message when set, e.g. to ack it
+	SendMessageFunc func(msg *slack.OutgoingMessage)
 	// Slice of rich messages
 	PostMessageFunc func(channel string, opts ...slack.MsgOption) (string, string, error)
+
+	ScheduleMessageFunc        func(channelID, postAt string, options ...slack.MsgOption) (string, string, error)
+	GetScheduledMessagesFunc   func(params *slack.GetScheduledMessagesParameters) ([]slack.ScheduledMessage, string, error)
+	DeleteScheduledMessageFunc func(params *slack.DeleteScheduledMessageParameters) (bool, error)
+	UploadFileFunc             func(params slack.FileUploadParameters) (*slack.File, error)
+	GetUsersFunc               func() ([]slack.User, error)
+	GetUserByEmailFunc         func(email string) (*slack.User, error)
+	GetUserGroupsFunc          func(options ...slack.GetUserGroupsOption) ([]slack.UserGroup, error)
+	GetConversationInfoFunc    func(channelID string, includeLocale bool) (*slack.Channel, error)
+	GetPermalinkFunc           func(params *slack.PermalinkParameters) (string, error)
+	GetFileInfoFunc            func(fileID string, count, page int) (*slack.File, []slack.Comment, *slack.Paging, error)
+	GetFileFunc                func(downloadURL string, writer io.Writer) error
+	AddPinFunc                 func(channel string, item slack.ItemRef) error
+	RemovePinFunc              func(channel string, item slack.ItemRef) error
+	ListPinsFunc               func(channel string) ([]slack.Item, *slack.Paging, error)
+	GetConversationRepliesFunc func(params *slack.GetConversationRepliesParameters) ([]slack.Message, bool, string, error)
+	CreateConversationFunc     func(channelName string, isPrivate bool) (*slack.Channel, error)
+	ArchiveConversationFunc    func(channelID string) error
+	UnArchiveConversationFunc  func(channelID string) error
+	RenameConversationFunc     func(channelID, channelName string) (*slack.Channel, error)
+	InviteUsersFunc            func(channelID string, users ...string) (*slack.Channel, error)
+	KickUserFunc               func(channelID string, user string) error
+	GetUserGroupMembersFunc    func(userGroup string) ([]string, error)
+	SetTopicFunc               func(channelID, topic string) (*slack.Channel, error)
+	SetPurposeFunc             func(channelID, purpose string) (*slack.Channel, error)
+	GetConversationsFunc       func(params *slack.GetConversationsParameters) ([]slack.Channel, string, error)
+	JoinConversationFunc       func(channelID string) (*slack.Channel, string, []string, error)
+	LeaveConversationFunc      func(channelID string) (bool, error)
+	GetUsersInConversationFunc func(params *slack.GetUsersInConversationParameters) ([]string, string, error)
+	GetConversationHistoryFunc func(params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error)
+	UpdateUserGroupMembersFunc func(userGroup string, members string) (slack.UserGroup, error)
+	CreateUserGroupFunc        func(userGroup slack.UserGroup) (slack.UserGroup, error)
+	DisableUserGroupFunc       func(userGroup string) (slack.UserGroup, error)
+	GetDNDInfoFunc             func(user *string) (*slack.DNDStatus, error)
 }
 
 func NewMockClient(t *testing.T) *MockClient {
@@ -300,6 +488,14 @@ func NewMockClient(t *testing.T) *MockClient {
 	m.PostMessageFunc = func(channel string, params ...slack.MsgOption) (string, string, error) {
 		return "", "", nil
 	}
+	m.GetConversationInfoFunc = func(channelID string, includeLocale bool) (*slack.Channel, error) {
+		ch := &slack.Channel{}
+		ch.ID = channelID
+		return ch, nil
+	}
+	m.GetPermalinkFunc = func(params *slack.PermalinkParameters) (string, error) {
+		return "", nil
+	}
 
 	return m
 }
@@ -339,6 +535,9 @@ func (m *MockClient) NewOutgoingMessage(message, channelId string, options ...sl
 
 func (m *MockClient) SendMessage(message *slack.OutgoingMessage) {
 	m.OutgoingMessages[message.Channel] = append(m.OutgoingMessages[message.Channel], message)
+	if m.SendMessageFunc != nil {
+		m.SendMessageFunc(message)
+	}
 }
 
 func (m *MockClient) PostMessage(channel string, opts ...slack.MsgOption) (string, string, error) {
@@ -346,5 +545,136 @@ func (m *MockClient) PostMessage(channel string, opts ...slack.MsgOption) (strin
 }
 
 func (m *MockClient) GetConversations(params *slack.GetConversationsParameters) (channels []slack.Channel, nextCursor string, err error) {
+	if m.GetConversationsFunc != nil {
+		return m.GetConversationsFunc(params)
+	}
 	return nil, "", err
 }
+
+func (m *MockClient) ScheduleMessage(channelID, postAt string, options ...slack.MsgOption) (string, string, error) {
+	return m.ScheduleMessageFunc(channelID, postAt, options...)
+}
+
+func (m *MockClient) GetScheduledMessages(params *slack.GetScheduledMessagesParameters) ([]slack.ScheduledMessage, string, error) {
+	return m.GetScheduledMessagesFunc(params)
+}
+
+func (m *MockClient) DeleteScheduledMessage(params *slack.DeleteScheduledMessageParameters) (bool, error) {
+	return m.DeleteScheduledMessageFunc(params)
+}
+
+func (m *MockClient) UploadFile(params slack.FileUploadParameters) (*slack.File, error) {
+	return m.UploadFileFunc(params)
+}
+
+func (m *MockClient) GetUsers() ([]slack.User, error) {
+	return m.GetUsersFunc()
+}
+
+func (m *MockClient) GetUserByEmail(email string) (*slack.User, error) {
+	return m.GetUserByEmailFunc(email)
+}
+
+func (m *MockClient) GetUserGroups(options ...slack.GetUserGroupsOption) ([]slack.UserGroup, error) {
+	return m.GetUserGroupsFunc(options...)
+}
+
+func (m *MockClient) GetConversationInfo(channelID string, includeLocale bool) (*slack.Channel, error) {
+	return m.GetConversationInfoFunc(channelID, includeLocale)
+}
+
+func (m *MockClient) GetPermalink(params *slack.PermalinkParameters) (string, error) {
+	return m.GetPermalinkFunc(params)
+}
+
+func (m *MockClient) GetFileInfo(fileID string, count, page int) (*slack.File, []slack.Comment, *slack.Paging, error) {
+	return m.GetFileInfoFunc(fileID, count, page)
+}
+
+func (m *MockClient) GetFile(downloadURL string, writer io.Writer) error {
+	return m.GetFileFunc(downloadURL, writer)
+}
+
+func (m *MockClient) AddPin(channel string, item slack.ItemRef) error {
+	return m.AddPinFunc(channel, item)
+}
+
+func (m *MockClient) RemovePin(channel string, item slack.ItemRef) error {
+	return m.RemovePinFunc(channel, item)
+}
+
+func (m *MockClient) ListPins(channel string) ([]slack.Item, *slack.Paging, error) {
+	return m.ListPinsFunc(channel)
+}
+
+func (m *MockClient) GetConversationReplies(params *slack.GetConversationRepliesParameters) ([]slack.Message, bool, string, error) {
+	return m.GetConversationRepliesFunc(params)
+}
+
+func (m *MockClient) CreateConversation(channelName string, isPrivate bool) (*slack.Channel, error) {
+	return m.CreateConversationFunc(channelName, isPrivate)
+}
+
+func (m *MockClient) ArchiveConversation(channelID string) error {
+	return m.ArchiveConversationFunc(channelID)
+}
+
+func (m *MockClient) UnArchiveConversation(channelID string) error {
+	return m.UnArchiveConversationFunc(channelID)
+}
+
+func (m *MockClient) RenameConversation(channelID, channelName string) (*slack.Channel, error) {
+	return m.RenameConversationFunc(channelID, channelName)
+}
+
+func (m *MockClient) InviteUsersToConversation(channelID string, users ...string) (*slack.Channel, error) {
+	return m.InviteUsersFunc(channelID, users...)
+}
+
+func (m *MockClient) KickUserFromConversation(channelID string, user string) error {
+	return m.KickUserFunc(channelID, user)
+}
+
+func (m *MockClient) GetUserGroupMembers(userGroup string) ([]string, error) {
+	return m.GetUserGroupMembersFunc(userGroup)
+}
+
+func (m *MockClient) SetTopicOfConversation(channelID, topic string) (*slack.Channel, error) {
+	return m.SetTopicFunc(channelID, topic)
+}
+
+func (m *MockClient) SetPurposeOfConversation(channelID, purpose string) (*slack.Channel, error) {
+	return m.SetPurposeFunc(channelID, purpose)
+}
+
+func (m *MockClient) JoinConversation(channelID string) (*slack.Channel, string, []string, error) {
+	return m.JoinConversationFunc(channelID)
+}
+
+func (m *MockClient) LeaveConversation(channelID string) (bool, error) {
+	return m.LeaveConversationFunc(channelID)
+}
+
+func (m *MockClient) GetUsersInConversation(params *slack.GetUsersInConversationParameters) ([]string, string, error) {
+	return m.GetUsersInConversationFunc(params)
+}
+
+func (m *MockClient) GetConversationHistory(params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+	return m.GetConversationHistoryFunc(params)
+}
+
+func (m *MockClient) UpdateUserGroupMembers(userGroup string, members string) (slack.UserGroup, error) {
+	return m.UpdateUserGroupMembersFunc(userGroup, members)
+}
+
+func (m *MockClient) CreateUserGroup(userGroup slack.UserGroup) (slack.UserGroup, error) {
+	return m.CreateUserGroupFunc(userGroup)
+}
+
+func (m *MockClient) DisableUserGroup(userGroup string) (slack.UserGroup, error) {
+	return m.DisableUserGroupFunc(userGroup)
+}
+
+func (m *MockClient) GetDNDInfo(user *string) (*slack.DNDStatus, error) {
+	return m.GetDNDInfoFunc(user)
+}
@@ -0,0 +1,92 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+	"github.com/rs/zerolog/log"
+	"github.com/slack-go/slack"
+)
+
+// maxThreadRoots caps number of cached thread roots, the cache is cleared once it's full
+const maxThreadRoots = 1000
+
+// threadRoot is the first message of a thread
+type threadRoot struct {
+	Timestamp  string `json:"timestamp"`
+	User       User   `json:"user"`
+	Text       string `json:"text"`
+	ReplyCount int    `json:"replyCount"`
+}
+
+// threadRoot returns copy of the root of the thread reply belongs to. Roots are fetched once per thread,
+// reply count of cached roots is kept up to date from replies received since.
+func (sl *slackClient) threadRoot(e *slack.MessageEvent) *threadRoot {
+	if !sl.cfg.ThreadContext || e.ThreadTimestamp == "" || e.ThreadTimestamp == e.Timestamp {
+		return nil
+	}
+
+	key := e.Channel + "/" + e.ThreadTimestamp
+	if root, ok := sl.threadRoots[key]; ok {
+		if e.SubType == "" || e.SubType == "thread_broadcast" {
+			root.ReplyCount++
+		}
+		out := *root
+		return &out
+	}
+
+	root, err := sl.fetchThreadRoot(e.Channel, e.ThreadTimestamp)
+	if err != nil {
+		log.Err(err).Msgf("cannot get root of thread=%s in channel=%s", e.ThreadTimestamp, e.Channel)
+		return nil
+	}
+
+	if len(sl.threadRoots) >= maxThreadRoots {
+		sl.threadRoots = make(map[string]*threadRoot)
+	}
+	sl.threadRoots[key] = root
+	out := *root
+	return &out
+}
+
+func (sl *slackClient) fetchThreadRoot(channelId, threadTimestamp string) (*threadRoot, error) {
+	msgs, _, _, err := sl.client.GetConversationReplies(&slack.GetConversationRepliesParameters{
+		ChannelID: channelId,
+		Timestamp: threadTimestamp,
+		Inclusive: true,
+		Limit:     1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(msgs) == 0 {
+		return nil, fmt.Errorf("thread=%s has no messages", threadTimestamp)
+	}
+
+	root := &threadRoot{
+		Timestamp:  msgs[0].Timestamp,
+		User:       User{Id: msgs[0].User},
+		Text:       msgs[0].Text,
+		ReplyCount: msgs[0].ReplyCount,
+	}
+	if u, err := sl.userInfo(msgs[0].User); err != nil {
+		log.Err(err).Msgf("cannot get info about user=%s", msgs[0].User)
+	} else {
+		root.User = newUser(u)
+	}
+	return root, nil
+}
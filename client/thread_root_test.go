@@ -0,0 +1,68 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestThreadReplyIncludesCachedThreadRoot(t *testing.T) {
+	Before(t)
+	SlackImpl.(*slackClient).cfg.ThreadContext = true
+	calls := 0
+	SlackMockClient.GetConversationRepliesFunc = func(params *slack.GetConversationRepliesParameters) ([]slack.Message, bool, string, error) {
+		calls++
+		assert.Equal(t, "C123", params.ChannelID)
+		assert.Equal(t, "1.0", params.Timestamp)
+		root := slack.Message{Msg: slack.Msg{Timestamp: "1.0", User: "U-author", Text: "deploy payments v42?", ReplyCount: 1}}
+		return []slack.Message{root}, true, "", nil
+	}
+	SlackMockClient.AddMockGetUserInfoCall("user-id-123", &slack.User{ID: "user-id-123"}, nil)
+	SlackMockClient.AddMockGetUserInfoCall("U-author", &slack.User{ID: "U-author", Name: "jdoe"}, nil)
+	SlackMockClient.AddMockGetUserInfoCall("user-id-123", &slack.User{ID: "user-id-123"}, nil)
+
+	events := SlackImpl.(*slackClient).incomingEvents
+	for _, ts := range []string{"2.0", "3.0"} {
+		events <- slack.RTMEvent{Type: "message", Data: &slack.MessageEvent{
+			Msg: slack.Msg{Channel: "C123", User: "user-id-123", Text: "approve", Timestamp: ts, ThreadTimestamp: "1.0"},
+		}}
+	}
+
+	first := nextEvent(t).Payload.(MessageEvent)
+	require.NotNil(t, first.ThreadRoot)
+	assert.Equal(t, threadRoot{Timestamp: "1.0", User: User{Id: "U-author", Name: "jdoe"}, Text: "deploy payments v42?", ReplyCount: 1}, *first.ThreadRoot)
+
+	second := nextEvent(t).Payload.(MessageEvent)
+	require.NotNil(t, second.ThreadRoot)
+	assert.Equal(t, "deploy payments v42?", second.ThreadRoot.Text)
+	assert.Equal(t, 2, second.ThreadRoot.ReplyCount)
+	assert.Equal(t, 1, calls)
+}
+
+func TestThreadRootIsNotFetchedWhenDisabled(t *testing.T) {
+	Before(t)
+	SlackMockClient.AddMockGetUserInfoCall("user-id-123", &slack.User{ID: "user-id-123"}, nil)
+
+	SlackImpl.(*slackClient).incomingEvents <- slack.RTMEvent{Type: "message", Data: &slack.MessageEvent{
+		Msg: slack.Msg{Channel: "C123", User: "user-id-123", Text: "approve", Timestamp: "2.0", ThreadTimestamp: "1.0"},
+	}}
+
+	assert.Nil(t, nextEvent(t).Payload.(MessageEvent).ThreadRoot)
+}
@@ -0,0 +1,80 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"fmt"
+	"github.com/ExpediaGroup/flyte-client/flyte"
+	"github.com/ExpediaGroup/flyte-slack/types"
+	"github.com/rs/zerolog/log"
+	"github.com/slack-go/slack"
+	"strings"
+)
+
+type userGroupUpdatedEvent struct {
+	UserGroup types.UserGroup `json:"userGroup"`
+}
+
+// ListUserGroups returns user groups with their user counts, unlike GetUserGroups it's not meant for the cache
+func (sl *slackClient) ListUserGroups(includeDisabled bool) ([]types.UserGroup, error) {
+	groups, err := sl.client.GetUserGroups(slack.GetUserGroupsOptionIncludeCount(true), slack.GetUserGroupsOptionIncludeDisabled(includeDisabled))
+	if err != nil {
+		return nil, fmt.Errorf("cannot list user groups: %v", err)
+	}
+	return toUserGroups(groups), nil
+}
+
+// UpdateUserGroupMembers replaces all members of the user group
+func (sl *slackClient) UpdateUserGroupMembers(userGroupId string, userIds []string) (*types.UserGroup, error) {
+	g, err := sl.client.UpdateUserGroupMembers(userGroupId, strings.Join(userIds, ","))
+	if err != nil {
+		return nil, fmt.Errorf("cannot update members of user group=%s: %v", userGroupId, err)
+	}
+	log.Info().Msgf("user group=%s members set to %v", userGroupId, userIds)
+	return sl.updatedUserGroup(&g), nil
+}
+
+func (sl *slackClient) CreateUserGroup(name, handle, description string) (*types.UserGroup, error) {
+	g, err := sl.client.CreateUserGroup(slack.UserGroup{Name: name, Handle: handle, Description: description})
+	if err != nil {
+		return nil, fmt.Errorf("cannot create user group=%s: %v", handle, err)
+	}
+	log.Info().Msgf("user group=%s created id=%s", handle, g.ID)
+	return sl.updatedUserGroup(&g), nil
+}
+
+func (sl *slackClient) DisableUserGroup(userGroupId string) (*types.UserGroup, error) {
+	g, err := sl.client.DisableUserGroup(userGroupId)
+	if err != nil {
+		return nil, fmt.Errorf("cannot disable user group=%s: %v", userGroupId, err)
+	}
+	log.Info().Msgf("user group=%s disabled", userGroupId)
+	return sl.updatedUserGroup(&g), nil
+}
+
+func (sl *slackClient) updatedUserGroup(g *slack.UserGroup) *types.UserGroup {
+	out := toUserGroup(g)
+	sl.cache.SetUserGroup(out)
+	return &out
+}
+
+func (sl *slackClient) toFlyteUserGroupUpdatedEvent(g *slack.UserGroup) flyte.Event {
+	return flyte.Event{
+		EventDef: flyte.EventDef{Name: "UserGroupUpdated"},
+		Payload:  userGroupUpdatedEvent{UserGroup: *sl.updatedUserGroup(g)},
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"errors"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestUserGroupUpdatedEventUpdatesCache(t *testing.T) {
+	Before(t)
+	SlackMockClient.GetUserGroupsFunc = func(options ...slack.GetUserGroupsOption) ([]slack.UserGroup, error) {
+		return []slack.UserGroup{{ID: "S1", Handle: "sre-oncall"}}, nil
+	}
+	c := SlackImpl.(*slackClient).cache
+	_, err := c.GetUserGroup("sre-oncall", SlackImpl)
+	require.NoError(t, err)
+
+	SlackImpl.(*slackClient).incomingEvents <- slack.RTMEvent{Type: "subteam_updated", Data: &slack.SubteamUpdatedEvent{
+		Subteam: slack.UserGroup{ID: "S1", Handle: "sre-primary", Name: "SRE primary", UserCount: 1, Users: []string{"U1"}},
+	}}
+
+	e := nextEvent(t)
+	require.Equal(t, "UserGroupUpdated", e.EventDef.Name)
+	g := e.Payload.(userGroupUpdatedEvent).UserGroup
+	assert.Equal(t, "sre-primary", g.Handle)
+	assert.Equal(t, []string{"U1"}, g.Users)
+	_, err = c.GetUserGroup("sre-oncall", SlackImpl)
+	assert.Error(t, err)
+	cached, err := c.GetUserGroup("sre-primary", SlackImpl)
+	require.NoError(t, err)
+	assert.Equal(t, "S1", cached.ID)
+}
+
+func TestDisableUserGroupDropsItFromCache(t *testing.T) {
+	Before(t)
+	SlackMockClient.GetUserGroupsFunc = func(options ...slack.GetUserGroupsOption) ([]slack.UserGroup, error) {
+		return []slack.UserGroup{{ID: "S1", Handle: "sre-oncall"}}, nil
+	}
+	SlackMockClient.DisableUserGroupFunc = func(userGroup string) (slack.UserGroup, error) {
+		return slack.UserGroup{ID: userGroup, Handle: "sre-oncall", DateDelete: 1600000000}, nil
+	}
+	c := SlackImpl.(*slackClient).cache
+	_, err := c.GetUserGroup("sre-oncall", SlackImpl)
+	require.NoError(t, err)
+
+	g, err := SlackImpl.DisableUserGroup("S1")
+
+	require.NoError(t, err)
+	assert.True(t, g.IsDisabled)
+	_, err = c.GetUserGroup("sre-oncall", SlackImpl)
+	assert.Error(t, err)
+}
+
+func TestUpdateUserGroupMembers(t *testing.T) {
+	Before(t)
+	var members string
+	SlackMockClient.UpdateUserGroupMembersFunc = func(userGroup string, m string) (slack.UserGroup, error) {
+		members = m
+		return slack.UserGroup{ID: userGroup, Handle: "sre-oncall", UserCount: 2}, nil
+	}
+
+	g, err := SlackImpl.UpdateUserGroupMembers("S1", []string{"U1", "U2"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "U1,U2", members)
+	assert.Equal(t, 2, g.UserCount)
+}
+
+func TestCreateUserGroupFails(t *testing.T) {
+	Before(t)
+	SlackMockClient.CreateUserGroupFunc = func(userGroup slack.UserGroup) (slack.UserGroup, error) {
+		return slack.UserGroup{}, errors.New("name_already_exists")
+	}
+
+	_, err := SlackImpl.CreateUserGroup("SRE on call", "sre-oncall", "")
+
+	require.Error(t, err)
+	assert.Equal(t, "cannot create user group=sre-oncall: name_already_exists", err.Error())
+}
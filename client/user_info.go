@@ -0,0 +1,255 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"github.com/slack-go/slack"
+	"strings"
+	"sync"
+	"time"
+)
+
+// userTTL is how long fetched users are kept, users changed while connected are updated straight away
+const userTTL = time.Hour
+
+var errNoSuchUser = errors.New("can't find user with such name")
+
+// userCache holds users by id, it's the only user store shared by command handlers and incoming events.
+// Users are also indexed by email, user name and display name, names are only known once all users were listed.
+type userCache struct {
+	mu    sync.Mutex
+	users map[string]cachedUser
+	// emails, names and displayNames map to user ids, entries are checked against the user before use
+	emails       map[string]string
+	names        map[string]string
+	displayNames map[string]string
+	// listed is when all users were last listed
+	listed time.Time
+}
+
+type cachedUser struct {
+	user    slack.User
+	fetched time.Time
+}
+
+func newUserCache() *userCache {
+	return &userCache{
+		users:        make(map[string]cachedUser),
+		emails:       make(map[string]string),
+		names:        make(map[string]string),
+		displayNames: make(map[string]string),
+	}
+}
+
+func (c *userCache) get(userId string) (*slack.User, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.fresh(userId)
+}
+
+// fresh returns copy of the user unless it's older than userTTL, c.mu has to be held
+func (c *userCache) fresh(userId string) (*slack.User, bool) {
+	cu, ok := c.users[userId]
+	if !ok || time.Since(cu.fetched) > userTTL {
+		return nil, false
+	}
+	u := cu.user
+	return &u, true
+}
+
+// last returns the last seen user regardless of how long ago it was fetched
+func (c *userCache) last(userId string) (*slack.User, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cu, ok := c.users[userId]
+	if !ok {
+		return nil, false
+	}
+	u := cu.user
+	return &u, true
+}
+
+func (c *userCache) set(u *slack.User) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.add(u, time.Now())
+}
+
+// setAll stores all users of the workspace, display names aren't unique so user names win over them
+func (c *userCache) setAll(users []slack.User) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	c.listed = now
+	for i := range users {
+		c.add(&users[i], now)
+	}
+}
+
+func (c *userCache) add(u *slack.User, fetched time.Time) {
+	c.users[u.ID] = cachedUser{user: *u, fetched: fetched}
+	if u.Profile.Email != "" {
+		c.emails[strings.ToLower(u.Profile.Email)] = u.ID
+	}
+	if u.Name != "" {
+		c.names[u.Name] = u.ID
+	}
+	if u.Profile.DisplayName != "" {
+		c.displayNames[u.Profile.DisplayName] = u.ID
+	}
+}
+
+func (c *userCache) byEmail(email string) (*slack.User, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	u, ok := c.fresh(c.emails[strings.ToLower(email)])
+	if !ok || !strings.EqualFold(u.Profile.Email, email) {
+		return nil, false
+	}
+	return u, true
+}
+
+// byName finds user by user name or display name, ok is false when the name isn't known or users should be listed again
+func (c *userCache) byName(name string) (*slack.User, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if u, ok := c.fresh(c.names[name]); ok && u.Name == name {
+		return u, true
+	}
+	if u, ok := c.fresh(c.displayNames[name]); ok && u.Profile.DisplayName == name {
+		return u, true
+	}
+	return nil, false
+}
+
+// listExpired tells whether all users should be listed again
+func (c *userCache) listExpired() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return time.Since(c.listed) > userTTL
+}
+
+// userInfo gets user from the user cache, fetching it on miss
+func (sl *slackClient) userInfo(userId string) (*slack.User, error) {
+	if u, ok := sl.users.get(userId); ok {
+		return u, nil
+	}
+
+	u, err := sl.client.GetUserInfo(userId)
+	if err != nil {
+		return nil, err
+	}
+	sl.users.set(u)
+	return u, nil
+}
+
+// listUsers fills the user cache with all users of the workspace unless they were listed within userTTL
+func (sl *slackClient) listUsers() error {
+	if !sl.users.listExpired() {
+		return nil
+	}
+
+	users, err := sl.client.GetUsers()
+	if err != nil {
+		return err
+	}
+	sl.users.setAll(users)
+	return nil
+}
+
+func (sl *slackClient) GetUserInfo(userId string) (*User, error) {
+	u, err := sl.userInfo(userId)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get info about user=%s: %v", userId, err)
+	}
+
+	out := newUser(u)
+	return &out, nil
+}
+
+// GetUsersInfo gets users through the user cache, all users are listed once when some aren't cached instead of
+// fetching them one by one. Users that can't be found are reported in failed, the rest keep their order.
+func (sl *slackClient) GetUsersInfo(userIds []string) ([]User, []UserFailure) {
+	var listErr error
+	for _, id := range userIds {
+		if _, ok := sl.users.get(id); !ok {
+			listErr = sl.listUsers()
+			break
+		}
+	}
+
+	users := []User{}
+	failed := []UserFailure{}
+	for _, id := range userIds {
+		u, ok := sl.users.get(id)
+		if !ok && listErr != nil {
+			failed = append(failed, UserFailure{User: id, Reason: fmt.Sprintf("cannot list users: %v", listErr)})
+			continue
+		}
+		if !ok {
+			// e.g. joined since users were listed
+			var err error
+			if u, err = sl.userInfo(id); err != nil {
+				failed = append(failed, UserFailure{User: id, Reason: err.Error()})
+				continue
+			}
+		}
+		users = append(users, newUser(u))
+	}
+	return users, failed
+}
+
+func (sl *slackClient) LookupUserByEmail(email string) (*User, error) {
+	if u, ok := sl.users.byEmail(email); ok {
+		out := newUser(u)
+		return &out, nil
+	}
+
+	u, err := sl.client.GetUserByEmail(email)
+	if err != nil {
+		return nil, fmt.Errorf("cannot find user with email=%s: %v", email, err)
+	}
+	sl.users.set(u)
+
+	out := newUser(u)
+	return &out, nil
+}
+
+// LookupUserByName finds user by user name or display name, all users are listed when the name isn't cached
+func (sl *slackClient) LookupUserByName(name string) (*User, error) {
+	u, ok := sl.users.byName(name)
+	if !ok {
+		if err := sl.listUsers(); err != nil {
+			return nil, fmt.Errorf("cannot find user=%s: %v", name, err)
+		}
+		if u, ok = sl.users.byName(name); !ok {
+			return nil, fmt.Errorf("cannot find user=%s: %v", name, errNoSuchUser)
+		}
+	}
+
+	out := newUser(u)
+	return &out, nil
+}
@@ -0,0 +1,172 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"errors"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestGetUserInfoIsCached(t *testing.T) {
+	Before(t)
+	SlackMockClient.AddMockGetUserInfoCall("U1", &slack.User{ID: "U1", Name: "jdoe", TZ: "Europe/London", IsBot: true,
+		Profile: slack.UserProfile{StatusEmoji: ":pager:", Image192: "https://avatars/jdoe.png"}}, nil)
+
+	first, err := SlackImpl.GetUserInfo("U1")
+	require.NoError(t, err)
+	second, err := SlackImpl.GetUserInfo("U1")
+	require.NoError(t, err)
+
+	assert.Equal(t, first, second)
+	assert.Equal(t, User{Id: "U1", Name: "jdoe", Timezone: "Europe/London", StatusEmoji: ":pager:", IsBot: true, AvatarUrl: "https://avatars/jdoe.png"}, *first)
+}
+
+func TestGetUserInfoFails(t *testing.T) {
+	Before(t)
+	SlackMockClient.AddMockGetUserInfoCall("U404", nil, errors.New("user_not_found"))
+
+	_, err := SlackImpl.GetUserInfo("U404")
+
+	require.Error(t, err)
+	assert.Equal(t, "cannot get info about user=U404: user_not_found", err.Error())
+}
+
+func TestUserChangedEventUpdatesUserCache(t *testing.T) {
+	Before(t)
+	SlackMockClient.GetUsersFunc = func() ([]slack.User, error) {
+		return nil, errors.New("ratelimited")
+	}
+
+	SlackImpl.(*slackClient).incomingEvents <- slack.RTMEvent{Type: "user_change", Data: &slack.UserChangeEvent{
+		User: slack.User{ID: "U1", Name: "jdoe", Profile: slack.UserProfile{StatusText: "on call"}},
+	}}
+	nextEvent(t)
+
+	u, err := SlackImpl.GetUserInfo("U1")
+
+	require.NoError(t, err)
+	assert.Equal(t, "on call", u.StatusText)
+}
+
+func TestLookupUserByEmailFillsUserCache(t *testing.T) {
+	Before(t)
+	SlackMockClient.GetUserByEmailFunc = func(email string) (*slack.User, error) {
+		return &slack.User{ID: "U1", Name: "jdoe", Profile: slack.UserProfile{Email: email}}, nil
+	}
+
+	byEmail, err := SlackImpl.LookupUserByEmail("jdoe@example.com")
+	require.NoError(t, err)
+	byId, err := SlackImpl.GetUserInfo("U1")
+	require.NoError(t, err)
+
+	assert.Equal(t, byEmail, byId)
+}
+
+func TestLookupUserByEmailReadsUserCache(t *testing.T) {
+	Before(t)
+	SlackImpl.(*slackClient).users.set(&slack.User{ID: "U1", Name: "jdoe", Profile: slack.UserProfile{Email: "jdoe@example.com"}})
+	SlackMockClient.GetUserByEmailFunc = func(email string) (*slack.User, error) {
+		assert.Fail(t, "user shouldn't be fetched")
+		return nil, nil
+	}
+
+	u, err := SlackImpl.LookupUserByEmail("JDoe@example.com")
+
+	require.NoError(t, err)
+	assert.Equal(t, "U1", u.Id)
+}
+
+func TestLookupUserByNameListsUsersOnce(t *testing.T) {
+	Before(t)
+	calls := 0
+	SlackMockClient.GetUsersFunc = func() ([]slack.User, error) {
+		calls++
+		return []slack.User{
+			{ID: "U1", Name: "jdoe", Profile: slack.UserProfile{DisplayName: "jsmith"}},
+			{ID: "U2", Name: "jsmith", Profile: slack.UserProfile{DisplayName: "John"}},
+		}, nil
+	}
+
+	byName, err := SlackImpl.LookupUserByName("jsmith")
+	require.NoError(t, err)
+	byDisplayName, err := SlackImpl.LookupUserByName("John")
+	require.NoError(t, err)
+	_, err = SlackImpl.LookupUserByName("nobody")
+	require.Error(t, err)
+
+	assert.Equal(t, "U2", byName.Id, "user names win over display names")
+	assert.Equal(t, "U2", byDisplayName.Id)
+	assert.Equal(t, "cannot find user=nobody: can't find user with such name", err.Error())
+	assert.Equal(t, 1, calls)
+	// users listed are shared with other lookups
+	u, err := SlackImpl.GetUserInfo("U1")
+	require.NoError(t, err)
+	assert.Equal(t, "jdoe", u.Name)
+}
+
+func TestLookupUserByNameFollowsRenames(t *testing.T) {
+	Before(t)
+	SlackMockClient.GetUsersFunc = func() ([]slack.User, error) {
+		return []slack.User{{ID: "U1", Name: "jdoe"}}, nil
+	}
+	_, err := SlackImpl.LookupUserByName("jdoe")
+	require.NoError(t, err)
+
+	SlackImpl.(*slackClient).users.set(&slack.User{ID: "U1", Name: "jane"})
+
+	_, err = SlackImpl.LookupUserByName("jdoe")
+	assert.Error(t, err)
+	u, err := SlackImpl.LookupUserByName("jane")
+	require.NoError(t, err)
+	assert.Equal(t, "U1", u.Id)
+}
+
+func TestGetUsersInfoListsUsersInsteadOfFetchingThemOneByOne(t *testing.T) {
+	Before(t)
+	SlackImpl.(*slackClient).users.set(&slack.User{ID: "U1", Name: "cached"})
+	SlackMockClient.GetUsersFunc = func() ([]slack.User, error) {
+		return []slack.User{{ID: "U2", Name: "jdoe"}, {ID: "U3", Name: "jane"}}, nil
+	}
+	// U4 joined since users were listed
+	SlackMockClient.AddMockGetUserInfoCall("U4", &slack.User{ID: "U4", Name: "newbie"}, nil)
+	SlackMockClient.AddMockGetUserInfoCall("U5", nil, errors.New("user_not_found"))
+
+	users, failed := SlackImpl.GetUsersInfo([]string{"U1", "U2", "U3", "U4", "U5"})
+
+	names := []string{}
+	for _, u := range users {
+		names = append(names, u.Name)
+	}
+	assert.Equal(t, []string{"cached", "jdoe", "jane", "newbie"}, names)
+	assert.Equal(t, []UserFailure{{User: "U5", Reason: "user_not_found"}}, failed)
+}
+
+func TestGetUsersInfoReportsUsersWhenListingFails(t *testing.T) {
+	Before(t)
+	SlackImpl.(*slackClient).users.set(&slack.User{ID: "U1", Name: "cached"})
+	SlackMockClient.GetUsersFunc = func() ([]slack.User, error) {
+		return nil, errors.New("ratelimited")
+	}
+
+	users, failed := SlackImpl.GetUsersInfo([]string{"U1", "U2"})
+
+	require.Len(t, users, 1)
+	assert.Equal(t, []UserFailure{{User: "U2", Reason: "cannot list users: ratelimited"}}, failed)
+}
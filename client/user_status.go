@@ -0,0 +1,69 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"errors"
+	"fmt"
+	"github.com/ExpediaGroup/flyte-slack/types"
+	"github.com/rs/zerolog/log"
+	"time"
+)
+
+// userClient makes calls bot tokens aren't allowed to, e.g. setting user status
+type userClient interface {
+	SetUserCustomStatusWithUser(user, statusText, statusEmoji string, statusExpiration int64) error
+}
+
+var errNoUserToken = errors.New("user token is not configured")
+
+// SetUserStatus sets custom status with the user token, zero expiration keeps the status until it's changed.
+// Empty userId sets status of the token owner, other users can only be changed with admin token.
+func (sl *slackClient) SetUserStatus(userId, text, emoji string, expiration time.Time) error {
+	if sl.userClient == nil {
+		return fmt.Errorf("cannot set status of user=%s: %v", userId, errNoUserToken)
+	}
+
+	var exp int64
+	if !expiration.IsZero() {
+		exp = expiration.Unix()
+	}
+	if err := sl.userClient.SetUserCustomStatusWithUser(userId, text, emoji, exp); err != nil {
+		return fmt.Errorf("cannot set status of user=%s: %v", userId, err)
+	}
+
+	log.Info().Msgf("status of user=%s set to %s %s", userId, emoji, text)
+	return nil
+}
+
+func (sl *slackClient) GetDndInfo(userId string) (*types.DndStatus, error) {
+	s, err := sl.client.GetDNDInfo(&userId)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get do not disturb info of user=%s: %v", userId, err)
+	}
+
+	now := time.Now().Unix()
+	scheduled := s.Enabled && int64(s.NextStartTimestamp) <= now && now < int64(s.NextEndTimestamp)
+	return &types.DndStatus{
+		Active:        s.SnoozeEnabled || scheduled,
+		DndEnabled:    s.Enabled,
+		NextDndStart:  s.NextStartTimestamp,
+		NextDndEnd:    s.NextEndTimestamp,
+		SnoozeEnabled: s.SnoozeEnabled,
+		SnoozeEnd:     s.SnoozeEndTime,
+	}, nil
+}
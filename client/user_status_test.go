@@ -0,0 +1,105 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+type mockUserClient struct {
+	user, text, emoji string
+	expiration        int64
+}
+
+func (m *mockUserClient) SetUserCustomStatusWithUser(user, statusText, statusEmoji string, statusExpiration int64) error {
+	m.user, m.text, m.emoji, m.expiration = user, statusText, statusEmoji, statusExpiration
+	return nil
+}
+
+func TestSetUserStatus(t *testing.T) {
+	Before(t)
+	uc := &mockUserClient{}
+	SlackImpl.(*slackClient).userClient = uc
+
+	err := SlackImpl.SetUserStatus("U1", "On call until 18:00", ":pager:", time.Unix(1600000000, 0))
+
+	require.NoError(t, err)
+	assert.Equal(t, &mockUserClient{user: "U1", text: "On call until 18:00", emoji: ":pager:", expiration: 1600000000}, uc)
+}
+
+func TestSetUserStatusWithoutExpiration(t *testing.T) {
+	Before(t)
+	uc := &mockUserClient{expiration: -1}
+	SlackImpl.(*slackClient).userClient = uc
+
+	require.NoError(t, SlackImpl.SetUserStatus("U1", "", "", time.Time{}))
+	assert.Equal(t, int64(0), uc.expiration)
+}
+
+func TestSetUserStatusFailsWithoutUserToken(t *testing.T) {
+	Before(t)
+
+	err := SlackImpl.SetUserStatus("U1", "On call", ":pager:", time.Time{})
+
+	require.Error(t, err)
+	assert.Equal(t, "cannot set status of user=U1: user token is not configured", err.Error())
+}
+
+func TestGetDndInfo(t *testing.T) {
+	Before(t)
+	now := int(time.Now().Unix())
+	SlackMockClient.GetDNDInfoFunc = func(user *string) (*slack.DNDStatus, error) {
+		assert.Equal(t, "U1", *user)
+		return &slack.DNDStatus{Enabled: true, NextStartTimestamp: now - 60, NextEndTimestamp: now + 60}, nil
+	}
+
+	s, err := SlackImpl.GetDndInfo("U1")
+
+	require.NoError(t, err)
+	assert.True(t, s.Active)
+	assert.Equal(t, now+60, s.NextDndEnd)
+}
+
+func TestGetDndInfoNotActiveOutsideSchedule(t *testing.T) {
+	Before(t)
+	now := int(time.Now().Unix())
+	SlackMockClient.GetDNDInfoFunc = func(user *string) (*slack.DNDStatus, error) {
+		return &slack.DNDStatus{Enabled: true, NextStartTimestamp: now + 60, NextEndTimestamp: now + 120}, nil
+	}
+
+	s, err := SlackImpl.GetDndInfo("U1")
+
+	require.NoError(t, err)
+	assert.False(t, s.Active)
+}
+
+func TestGetDndInfoActiveWhenSnoozed(t *testing.T) {
+	Before(t)
+	SlackMockClient.GetDNDInfoFunc = func(user *string) (*slack.DNDStatus, error) {
+		return &slack.DNDStatus{SnoozeInfo: slack.SnoozeInfo{SnoozeEnabled: true, SnoozeEndTime: 1600000000}}, nil
+	}
+
+	s, err := SlackImpl.GetDndInfo("U1")
+
+	require.NoError(t, err)
+	assert.True(t, s.Active)
+	assert.Equal(t, 1600000000, s.SnoozeEnd)
+}
@@ -0,0 +1,55 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"github.com/ExpediaGroup/flyte-slack/types"
+	"github.com/slack-go/slack"
+)
+
+func (sl *slackClient) GetUserGroups() ([]types.UserGroup, error) {
+	groups, err := sl.client.GetUserGroups()
+	if err != nil {
+		return nil, err
+	}
+
+	return toUserGroups(groups), nil
+}
+
+func (sl *slackClient) GetUserGroupMembers(userGroupId string) ([]string, error) {
+	return sl.client.GetUserGroupMembers(userGroupId)
+}
+
+func toUserGroups(groups []slack.UserGroup) []types.UserGroup {
+	out := make([]types.UserGroup, 0, len(groups))
+	for i := range groups {
+		out = append(out, toUserGroup(&groups[i]))
+	}
+	return out
+}
+
+func toUserGroup(g *slack.UserGroup) types.UserGroup {
+	return types.UserGroup{
+		ID:          g.ID,
+		Handle:      g.Handle,
+		Name:        g.Name,
+		Description: g.Description,
+		UserCount:   g.UserCount,
+		IsDisabled:  g.DateDelete != 0,
+		Users:       g.Users,
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"github.com/ExpediaGroup/flyte-client/flyte"
+	"github.com/slack-go/slack"
+	"strconv"
+)
+
+type userJoinedWorkspaceEvent struct {
+	User User `json:"user"`
+}
+
+type userChangedEvent struct {
+	User User `json:"user"`
+	// Changes maps changed profile fields to their old and new values, empty when previous profile isn't known
+	Changes map[string]profileChange `json:"changes"`
+}
+
+type profileChange struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+func toFlyteUserJoinedWorkspaceEvent(u *slack.User) flyte.Event {
+	return flyte.Event{
+		EventDef: flyte.EventDef{Name: "UserJoinedWorkspace"},
+		Payload:  userJoinedWorkspaceEvent{User: newUser(u)},
+	}
+}
+
+func toFlyteUserChangedEvent(u *slack.User, changes map[string]profileChange) flyte.Event {
+	return flyte.Event{
+		EventDef: flyte.EventDef{Name: "UserChanged"},
+		Payload:  userChangedEvent{User: newUser(u), Changes: changes},
+	}
+}
+
+// profileChanges diffs user profile against the last one seen in the user cache, known is false if the user
+// hasn't been seen since start and there's nothing to compare with
+func (sl *slackClient) profileChanges(u *slack.User) (changes map[string]profileChange, known bool) {
+	previous, known := sl.users.last(u.ID)
+	if !known {
+		return map[string]profileChange{}, false
+	}
+	return profileDiff(previous.Profile, u.Profile), true
+}
+
+func profileDiff(old, new slack.UserProfile) map[string]profileChange {
+	o, n := profileFields(old), profileFields(new)
+	changes := map[string]profileChange{}
+	for k := range n {
+		if o[k] != n[k] {
+			changes[k] = profileChange{Old: o[k], New: n[k]}
+		}
+	}
+	return changes
+}
+
+func profileFields(p slack.UserProfile) map[string]string {
+	return map[string]string{
+		"firstName":        p.FirstName,
+		"lastName":         p.LastName,
+		"realName":         p.RealName,
+		"displayName":      p.DisplayName,
+		"email":            p.Email,
+		"title":            p.Title,
+		"phone":            p.Phone,
+		"statusText":       p.StatusText,
+		"statusEmoji":      p.StatusEmoji,
+		"statusExpiration": strconv.Itoa(p.StatusExpiration),
+	}
+}
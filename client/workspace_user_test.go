@@ -0,0 +1,84 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestUserJoinedWorkspaceEvent(t *testing.T) {
+	Before(t)
+
+	SlackImpl.(*slackClient).incomingEvents <- slack.RTMEvent{Type: "team_join", Data: &slack.TeamJoinEvent{
+		User: slack.User{ID: "U1", Name: "jdoe", Profile: slack.UserProfile{Email: "jdoe@example.com", Title: "SRE"}},
+	}}
+
+	e := nextEvent(t)
+	require.Equal(t, "UserJoinedWorkspace", e.EventDef.Name)
+	assert.Equal(t, User{Id: "U1", Name: "jdoe", Email: "jdoe@example.com", Title: "SRE"}, e.Payload.(userJoinedWorkspaceEvent).User)
+}
+
+func TestUserChangedEventHasProfileDiff(t *testing.T) {
+	Before(t)
+	SlackImpl.(*slackClient).users.set(&slack.User{ID: "U1", Name: "jdoe", Profile: slack.UserProfile{Title: "SRE", StatusText: ""}})
+	changed := slack.User{ID: "U1", Name: "jdoe", Profile: slack.UserProfile{Title: "SRE", StatusText: "on call", StatusEmoji: ":pager:"}}
+
+	events := SlackImpl.(*slackClient).incomingEvents
+	events <- slack.RTMEvent{Type: "user_change", Data: &slack.UserChangeEvent{User: changed}}
+
+	e := nextEvent(t)
+	require.Equal(t, "UserChanged", e.EventDef.Name)
+	payload := e.Payload.(userChangedEvent)
+	assert.Equal(t, "jdoe", payload.User.Name)
+	assert.Equal(t, map[string]profileChange{
+		"statusText":  {Old: "", New: "on call"},
+		"statusEmoji": {Old: "", New: ":pager:"},
+	}, payload.Changes)
+
+	// nothing changed in profile since
+	events <- slack.RTMEvent{Type: "user_change", Data: &slack.UserChangeEvent{User: changed}}
+	select {
+	case e := <-SlackImpl.IncomingMessages():
+		assert.Fail(t, "unexpected event", e.EventDef.Name)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestUserChangedEventWithoutPreviousProfile(t *testing.T) {
+	Before(t)
+	SlackMockClient.GetUsersFunc = func() ([]slack.User, error) {
+		assert.Fail(t, "users shouldn't be listed")
+		return nil, nil
+	}
+
+	SlackImpl.(*slackClient).incomingEvents <- slack.RTMEvent{Type: "user_change", Data: &slack.UserChangeEvent{
+		User: slack.User{ID: "U1", Profile: slack.UserProfile{Title: "SRE"}},
+	}}
+
+	e := nextEvent(t)
+	require.Equal(t, "UserChanged", e.EventDef.Name)
+	assert.Empty(t, e.Payload.(userChangedEvent).Changes)
+
+	// the changed user is remembered for the next change
+	previous, ok := SlackImpl.(*slackClient).users.last("U1")
+	require.True(t, ok)
+	assert.Equal(t, "SRE", previous.Profile.Title)
+}
@@ -0,0 +1,142 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ExpediaGroup/flyte-client/flyte"
+	"github.com/ExpediaGroup/flyte-slack/cache"
+	"github.com/ExpediaGroup/flyte-slack/client"
+	"github.com/ExpediaGroup/flyte-slack/types"
+	"strings"
+)
+
+var (
+	joinChannelSuccessEventDef  = flyte.EventDef{Name: "JoinChannelSuccess"}
+	joinChannelFailedEventDef   = flyte.EventDef{Name: "JoinChannelFailed"}
+	leaveChannelSuccessEventDef = flyte.EventDef{Name: "LeaveChannelSuccess"}
+	leaveChannelFailedEventDef  = flyte.EventDef{Name: "LeaveChannelFailed"}
+)
+
+// ChannelInput identifies channel by id or name, id is filled in when channel is given by name
+type ChannelInput struct {
+	ChannelId   string `json:"channelId"`
+	ChannelName string `json:"channelName"`
+}
+
+type JoinChannelSuccess struct {
+	ChannelInput
+	Conversation *types.Conversation `json:"conversation"`
+}
+
+type ChannelFail struct {
+	ChannelInput
+	Reason string `json:"reason"`
+}
+
+func JoinChannel(slack client.Slack, cache cache.Cache) flyte.Command {
+	return flyte.Command{
+		Name:         "JoinChannel",
+		OutputEvents: []flyte.EventDef{joinChannelSuccessEventDef, joinChannelFailedEventDef},
+		Handler:      joinChannelHandler(slack, cache),
+	}
+}
+
+func joinChannelHandler(slack client.Slack, cache cache.Cache) func(json.RawMessage) flyte.Event {
+	return func(rawInput json.RawMessage) flyte.Event {
+		input := ChannelInput{}
+		if err := json.Unmarshal(rawInput, &input); err != nil {
+			return flyte.NewFatalEvent(fmt.Sprintf("input is not valid: %v", err))
+		}
+
+		if err := resolveChannelId(&input, slack, cache); err != nil {
+			return newChannelFail(joinChannelFailedEventDef, input, err.Error())
+		}
+
+		conv, err := slack.JoinChannel(input.ChannelId)
+		if err != nil {
+			return newChannelFail(joinChannelFailedEventDef, input, err.Error())
+		}
+
+		return flyte.Event{
+			EventDef: joinChannelSuccessEventDef,
+			Payload: JoinChannelSuccess{
+				ChannelInput: input,
+				Conversation: conv,
+			},
+		}
+	}
+}
+
+func LeaveChannel(slack client.Slack, cache cache.Cache) flyte.Command {
+	return flyte.Command{
+		Name:         "LeaveChannel",
+		OutputEvents: []flyte.EventDef{leaveChannelSuccessEventDef, leaveChannelFailedEventDef},
+		Handler:      leaveChannelHandler(slack, cache),
+	}
+}
+
+func leaveChannelHandler(slack client.Slack, cache cache.Cache) func(json.RawMessage) flyte.Event {
+	return func(rawInput json.RawMessage) flyte.Event {
+		input := ChannelInput{}
+		if err := json.Unmarshal(rawInput, &input); err != nil {
+			return flyte.NewFatalEvent(fmt.Sprintf("input is not valid: %v", err))
+		}
+
+		if err := resolveChannelId(&input, slack, cache); err != nil {
+			return newChannelFail(leaveChannelFailedEventDef, input, err.Error())
+		}
+
+		if err := slack.LeaveChannel(input.ChannelId); err != nil {
+			return newChannelFail(leaveChannelFailedEventDef, input, err.Error())
+		}
+
+		return flyte.Event{
+			EventDef: leaveChannelSuccessEventDef,
+			Payload:  input,
+		}
+	}
+}
+
+// resolveChannelId looks up channel id by name when only name is given
+func resolveChannelId(input *ChannelInput, slack client.Slack, cache cache.Cache) error {
+	if input.ChannelId != "" {
+		return nil
+	}
+	if input.ChannelName == "" {
+		return errors.New("missing channel id or channel name field")
+	}
+
+	conv, err := cache.GetChannelID(strings.TrimPrefix(input.ChannelName, "#"), slack)
+	if err != nil {
+		return fmt.Errorf("cannot find channel=%s: %v", input.ChannelName, err)
+	}
+	input.ChannelId = conv.ID
+	return nil
+}
+
+func newChannelFail(def flyte.EventDef, input ChannelInput, reason string) flyte.Event {
+	return flyte.Event{
+		EventDef: def,
+		Payload: ChannelFail{
+			ChannelInput: input,
+			Reason:       reason,
+		},
+	}
+}
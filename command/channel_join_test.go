@@ -0,0 +1,84 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"errors"
+	"github.com/ExpediaGroup/flyte-slack/cache"
+	"github.com/ExpediaGroup/flyte-slack/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func newTestCache() cache.Cache {
+	return cache.New(&cache.Config{RenewConversationListFrequency: time.Hour})
+}
+
+func TestJoinChannelByName(t *testing.T) {
+	slack := NewMockSlack()
+	slack.GetConversationsFunc = func() ([]types.Conversation, error) {
+		return []types.Conversation{{ID: "C123", Name: "sre"}}, nil
+	}
+	slack.JoinChannelFunc = func(channelId string) (*types.Conversation, error) {
+		return &types.Conversation{ID: channelId, Name: "sre"}, nil
+	}
+
+	event := JoinChannel(slack, newTestCache()).Handler([]byte(`{"channelName": "#sre"}`))
+
+	require.Equal(t, joinChannelSuccessEventDef, event.EventDef)
+	output := event.Payload.(JoinChannelSuccess)
+	assert.Equal(t, "C123", output.ChannelId)
+	assert.Equal(t, "sre", output.Conversation.Name)
+}
+
+func TestJoinChannelFailsForUnknownName(t *testing.T) {
+	event := JoinChannel(NewMockSlack(), newTestCache()).Handler([]byte(`{"channelName": "nope"}`))
+
+	require.Equal(t, joinChannelFailedEventDef, event.EventDef)
+	assert.Equal(t, "cannot find channel=nope: can't find channel with such name", event.Payload.(ChannelFail).Reason)
+}
+
+func TestLeaveChannel(t *testing.T) {
+	slack := NewMockSlack()
+	var left string
+	slack.LeaveChannelFunc = func(channelId string) error {
+		left = channelId
+		return nil
+	}
+
+	event := LeaveChannel(slack, newTestCache()).Handler([]byte(`{"channelId": "C123"}`))
+
+	assert.Equal(t, leaveChannelSuccessEventDef, event.EventDef)
+	assert.Equal(t, "C123", left)
+}
+
+func TestLeaveChannelFails(t *testing.T) {
+	slack := NewMockSlack()
+	slack.LeaveChannelFunc = func(channelId string) error {
+		return errors.New("cannot leave channel=C123: cant_leave_general")
+	}
+
+	missing := LeaveChannel(slack, newTestCache()).Handler([]byte(`{}`))
+	failed := LeaveChannel(slack, newTestCache()).Handler([]byte(`{"channelId": "C123"}`))
+
+	require.Equal(t, leaveChannelFailedEventDef, missing.EventDef)
+	assert.Equal(t, "missing channel id or channel name field", missing.Payload.(ChannelFail).Reason)
+	require.Equal(t, leaveChannelFailedEventDef, failed.EventDef)
+	assert.Equal(t, "cannot leave channel=C123: cant_leave_general", failed.Payload.(ChannelFail).Reason)
+}
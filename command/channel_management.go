@@ -0,0 +1,232 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/ExpediaGroup/flyte-client/flyte"
+	"github.com/ExpediaGroup/flyte-slack/client"
+	"github.com/ExpediaGroup/flyte-slack/types"
+	"strings"
+)
+
+// event names follow GetChannelInfo, ChannelCreated etc. are events coming from slack
+var (
+	createChannelSuccessEventDef    = flyte.EventDef{Name: "CreateChannelSuccess"}
+	createChannelFailedEventDef     = flyte.EventDef{Name: "CreateChannelFailed"}
+	archiveChannelSuccessEventDef   = flyte.EventDef{Name: "ArchiveChannelSuccess"}
+	archiveChannelFailedEventDef    = flyte.EventDef{Name: "ArchiveChannelFailed"}
+	unarchiveChannelSuccessEventDef = flyte.EventDef{Name: "UnarchiveChannelSuccess"}
+	unarchiveChannelFailedEventDef  = flyte.EventDef{Name: "UnarchiveChannelFailed"}
+	renameChannelSuccessEventDef    = flyte.EventDef{Name: "RenameChannelSuccess"}
+	renameChannelFailedEventDef     = flyte.EventDef{Name: "RenameChannelFailed"}
+)
+
+type CreateChannelInput struct {
+	Name      string   `json:"name"`
+	IsPrivate bool     `json:"isPrivate"`
+	MemberIds []string `json:"memberIds"`
+}
+
+// CreateChannelSuccess is sent once the channel is created, even if some members couldn't be invited
+type CreateChannelSuccess struct {
+	CreateChannelInput
+	Conversation *types.Conversation  `json:"conversation"`
+	Invited      []string             `json:"invited"`
+	Failed       []client.UserFailure `json:"failed"`
+}
+
+type CreateChannelFail struct {
+	CreateChannelInput
+	Reason string `json:"reason"`
+}
+
+// ArchiveChannelInput is input of both ArchiveChannel and UnarchiveChannel
+type ArchiveChannelInput struct {
+	ChannelId string `json:"channelId"`
+}
+
+type ArchiveChannelSuccess struct {
+	ArchiveChannelInput
+}
+
+type ArchiveChannelFail struct {
+	ArchiveChannelInput
+	Reason string `json:"reason"`
+}
+
+type RenameChannelInput struct {
+	ChannelId string `json:"channelId"`
+	Name      string `json:"name"`
+}
+
+type RenameChannelSuccess struct {
+	RenameChannelInput
+	Conversation *types.Conversation `json:"conversation"`
+}
+
+type RenameChannelFail struct {
+	RenameChannelInput
+	Reason string `json:"reason"`
+}
+
+func CreateChannel(slack client.Slack) flyte.Command {
+	return flyte.Command{
+		Name:         "CreateChannel",
+		OutputEvents: []flyte.EventDef{createChannelSuccessEventDef, createChannelFailedEventDef},
+		Handler:      createChannelHandler(slack),
+	}
+}
+
+func createChannelHandler(slack client.Slack) func(json.RawMessage) flyte.Event {
+	return func(rawInput json.RawMessage) flyte.Event {
+		input := CreateChannelInput{}
+		if err := json.Unmarshal(rawInput, &input); err != nil {
+			return flyte.NewFatalEvent(fmt.Sprintf("input is not valid: %v", err))
+		}
+
+		if input.Name == "" {
+			return newCreateChannelFail(input, "missing name field")
+		}
+
+		conv, invited, failed, err := slack.CreateChannel(input.Name, input.IsPrivate, input.MemberIds)
+		if err != nil {
+			return newCreateChannelFail(input, err.Error())
+		}
+
+		return flyte.Event{
+			EventDef: createChannelSuccessEventDef,
+			Payload: CreateChannelSuccess{
+				CreateChannelInput: input,
+				Conversation:       conv,
+				Invited:            invited,
+				Failed:             failed,
+			},
+		}
+	}
+}
+
+func newCreateChannelFail(input CreateChannelInput, reason string) flyte.Event {
+	return flyte.Event{
+		EventDef: createChannelFailedEventDef,
+		Payload: CreateChannelFail{
+			CreateChannelInput: input,
+			Reason:             reason,
+		},
+	}
+}
+
+func ArchiveChannel(slack client.Slack) flyte.Command {
+	return flyte.Command{
+		Name:         "ArchiveChannel",
+		OutputEvents: []flyte.EventDef{archiveChannelSuccessEventDef, archiveChannelFailedEventDef},
+		Handler:      archiveChannelHandler(slack.ArchiveChannel, archiveChannelSuccessEventDef, archiveChannelFailedEventDef),
+	}
+}
+
+func UnarchiveChannel(slack client.Slack) flyte.Command {
+	return flyte.Command{
+		Name:         "UnarchiveChannel",
+		OutputEvents: []flyte.EventDef{unarchiveChannelSuccessEventDef, unarchiveChannelFailedEventDef},
+		Handler:      archiveChannelHandler(slack.UnarchiveChannel, unarchiveChannelSuccessEventDef, unarchiveChannelFailedEventDef),
+	}
+}
+
+// archiveChannelHandler is shared by ArchiveChannel and UnarchiveChannel, which differ only in slack call and events
+func archiveChannelHandler(archive func(channelId string) error, success, fail flyte.EventDef) func(json.RawMessage) flyte.Event {
+	return func(rawInput json.RawMessage) flyte.Event {
+		input := ArchiveChannelInput{}
+		if err := json.Unmarshal(rawInput, &input); err != nil {
+			return flyte.NewFatalEvent(fmt.Sprintf("input is not valid: %v", err))
+		}
+
+		if input.ChannelId == "" {
+			return newArchiveChannelFail(fail, input, "missing channel id field")
+		}
+
+		if err := archive(input.ChannelId); err != nil {
+			return newArchiveChannelFail(fail, input, err.Error())
+		}
+
+		return flyte.Event{
+			EventDef: success,
+			Payload:  ArchiveChannelSuccess{ArchiveChannelInput: input},
+		}
+	}
+}
+
+func newArchiveChannelFail(fail flyte.EventDef, input ArchiveChannelInput, reason string) flyte.Event {
+	return flyte.Event{
+		EventDef: fail,
+		Payload: ArchiveChannelFail{
+			ArchiveChannelInput: input,
+			Reason:              reason,
+		},
+	}
+}
+
+func RenameChannel(slack client.Slack) flyte.Command {
+	return flyte.Command{
+		Name:         "RenameChannel",
+		OutputEvents: []flyte.EventDef{renameChannelSuccessEventDef, renameChannelFailedEventDef},
+		Handler:      renameChannelHandler(slack),
+	}
+}
+
+func renameChannelHandler(slack client.Slack) func(json.RawMessage) flyte.Event {
+	return func(rawInput json.RawMessage) flyte.Event {
+		input := RenameChannelInput{}
+		if err := json.Unmarshal(rawInput, &input); err != nil {
+			return flyte.NewFatalEvent(fmt.Sprintf("input is not valid: %v", err))
+		}
+
+		errorMessages := []string{}
+		if input.ChannelId == "" {
+			errorMessages = append(errorMessages, "missing channel id field")
+		}
+		if input.Name == "" {
+			errorMessages = append(errorMessages, "missing name field")
+		}
+		if len(errorMessages) != 0 {
+			return newRenameChannelFail(input, strings.Join(errorMessages, ", "))
+		}
+
+		conv, err := slack.RenameChannel(input.ChannelId, input.Name)
+		if err != nil {
+			return newRenameChannelFail(input, err.Error())
+		}
+
+		return flyte.Event{
+			EventDef: renameChannelSuccessEventDef,
+			Payload: RenameChannelSuccess{
+				RenameChannelInput: input,
+				Conversation:       conv,
+			},
+		}
+	}
+}
+
+func newRenameChannelFail(input RenameChannelInput, reason string) flyte.Event {
+	return flyte.Event{
+		EventDef: renameChannelFailedEventDef,
+		Payload: RenameChannelFail{
+			RenameChannelInput: input,
+			Reason:             reason,
+		},
+	}
+}
@@ -0,0 +1,114 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"errors"
+	"github.com/ExpediaGroup/flyte-slack/client"
+	"github.com/ExpediaGroup/flyte-slack/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCreateChannel(t *testing.T) {
+	slack := NewMockSlack()
+	slack.CreateChannelFunc = func(name string, isPrivate bool, memberIds []string) (*types.Conversation, []string, []client.UserFailure, error) {
+		assert.Equal(t, "inc-2026-10-17-payments", name)
+		assert.True(t, isPrivate)
+		assert.Equal(t, []string{"U1", "U2"}, memberIds)
+		return &types.Conversation{ID: "C123", Name: name, Type: "group"}, memberIds, []client.UserFailure{}, nil
+	}
+
+	event := CreateChannel(slack).Handler([]byte(`{"name": "inc-2026-10-17-payments", "isPrivate": true, "memberIds": ["U1", "U2"]}`))
+
+	require.Equal(t, createChannelSuccessEventDef, event.EventDef)
+	assert.Equal(t, "C123", event.Payload.(CreateChannelSuccess).Conversation.ID)
+}
+
+func TestCreateChannelSucceedsWhenMembersCannotBeInvited(t *testing.T) {
+	slack := NewMockSlack()
+	slack.CreateChannelFunc = func(name string, isPrivate bool, memberIds []string) (*types.Conversation, []string, []client.UserFailure, error) {
+		return &types.Conversation{ID: "C123", Name: name}, []string{}, []client.UserFailure{{User: "U1", Reason: "user_not_found"}}, nil
+	}
+
+	event := CreateChannel(slack).Handler([]byte(`{"name": "inc-payments", "memberIds": ["U1"]}`))
+
+	require.Equal(t, createChannelSuccessEventDef, event.EventDef)
+	output := event.Payload.(CreateChannelSuccess)
+	assert.Equal(t, "C123", output.Conversation.ID)
+	assert.Equal(t, []client.UserFailure{{User: "U1", Reason: "user_not_found"}}, output.Failed)
+}
+
+func TestCreateChannelFails(t *testing.T) {
+	slack := NewMockSlack()
+	slack.CreateChannelFunc = func(name string, isPrivate bool, memberIds []string) (*types.Conversation, []string, []client.UserFailure, error) {
+		return nil, nil, nil, errors.New("cannot create channel=inc-payments: name_taken")
+	}
+
+	event := CreateChannel(slack).Handler([]byte(`{"name": "inc-payments"}`))
+
+	require.Equal(t, createChannelFailedEventDef, event.EventDef)
+	assert.Equal(t, "cannot create channel=inc-payments: name_taken", event.Payload.(CreateChannelFail).Reason)
+}
+
+func TestCreateChannelFailsWithoutName(t *testing.T) {
+	event := CreateChannel(NewMockSlack()).Handler([]byte(`{"isPrivate": true}`))
+
+	require.Equal(t, createChannelFailedEventDef, event.EventDef)
+	assert.Equal(t, "missing name field", event.Payload.(CreateChannelFail).Reason)
+}
+
+func TestArchiveAndUnarchiveChannel(t *testing.T) {
+	slack := NewMockSlack()
+	var calls []string
+	slack.ArchiveChannelFunc = func(channelId string) error {
+		calls = append(calls, "archive "+channelId)
+		return nil
+	}
+	slack.UnarchiveChannelFunc = func(channelId string) error {
+		calls = append(calls, "unarchive "+channelId)
+		return errors.New("cannot unarchive channel=C123: not_archived")
+	}
+
+	archived := ArchiveChannel(slack).Handler([]byte(`{"channelId": "C123"}`))
+	unarchived := UnarchiveChannel(slack).Handler([]byte(`{"channelId": "C123"}`))
+
+	assert.Equal(t, archiveChannelSuccessEventDef, archived.EventDef)
+	require.Equal(t, unarchiveChannelFailedEventDef, unarchived.EventDef)
+	assert.Equal(t, "cannot unarchive channel=C123: not_archived", unarchived.Payload.(ArchiveChannelFail).Reason)
+	assert.Equal(t, []string{"archive C123", "unarchive C123"}, calls)
+}
+
+func TestRenameChannel(t *testing.T) {
+	slack := NewMockSlack()
+	slack.RenameChannelFunc = func(channelId, name string) (*types.Conversation, error) {
+		return &types.Conversation{ID: channelId, Name: name}, nil
+	}
+
+	event := RenameChannel(slack).Handler([]byte(`{"channelId": "C123", "name": "inc-payments-resolved"}`))
+
+	require.Equal(t, renameChannelSuccessEventDef, event.EventDef)
+	assert.Equal(t, "inc-payments-resolved", event.Payload.(RenameChannelSuccess).Conversation.Name)
+}
+
+func TestRenameChannelFailsWithoutFields(t *testing.T) {
+	event := RenameChannel(NewMockSlack()).Handler([]byte(`{}`))
+
+	require.Equal(t, renameChannelFailedEventDef, event.EventDef)
+	assert.Equal(t, "missing channel id field, missing name field", event.Payload.(RenameChannelFail).Reason)
+}
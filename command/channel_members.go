@@ -0,0 +1,218 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/ExpediaGroup/flyte-client/flyte"
+	"github.com/ExpediaGroup/flyte-slack/cache"
+	"github.com/ExpediaGroup/flyte-slack/client"
+	"strings"
+)
+
+var (
+	inviteToChannelSuccessEventDef   = flyte.EventDef{Name: "InviteToChannelSuccess"}
+	inviteToChannelFailedEventDef    = flyte.EventDef{Name: "InviteToChannelFailed"}
+	removeFromChannelSuccessEventDef = flyte.EventDef{Name: "RemoveFromChannelSuccess"}
+	removeFromChannelFailedEventDef  = flyte.EventDef{Name: "RemoveFromChannelFailed"}
+)
+
+// ChannelMembersInput is input of both InviteToChannel and RemoveFromChannel, users can be given by any mix of ids,
+// emails and user group handles
+type ChannelMembersInput struct {
+	ChannelId  string   `json:"channelId"`
+	UserIds    []string `json:"userIds"`
+	Emails     []string `json:"emails"`
+	UserGroups []string `json:"userGroups"`
+}
+
+type InviteToChannelSuccess struct {
+	ChannelMembersInput
+	Invited []string             `json:"invited"`
+	Failed  []client.UserFailure `json:"failed"`
+}
+
+type RemoveFromChannelSuccess struct {
+	ChannelMembersInput
+	Removed []string             `json:"removed"`
+	Failed  []client.UserFailure `json:"failed"`
+}
+
+// InviteToChannelFail lists users handled before the channel error, they're left out for invalid input
+type InviteToChannelFail struct {
+	ChannelMembersInput
+	Invited []string             `json:"invited,omitempty"`
+	Failed  []client.UserFailure `json:"failed,omitempty"`
+	Reason  string               `json:"reason"`
+}
+
+// RemoveFromChannelFail lists users handled before the channel error, they're left out for invalid input
+type RemoveFromChannelFail struct {
+	ChannelMembersInput
+	Removed []string             `json:"removed,omitempty"`
+	Failed  []client.UserFailure `json:"failed,omitempty"`
+	Reason  string               `json:"reason"`
+}
+
+func InviteToChannel(slack client.Slack, cache cache.Cache) flyte.Command {
+	return flyte.Command{
+		Name:         "InviteToChannel",
+		OutputEvents: []flyte.EventDef{inviteToChannelSuccessEventDef, inviteToChannelFailedEventDef},
+		Handler:      channelMembersHandler(slack, cache, slack.InviteToChannel, newInviteToChannelSuccess, newInviteToChannelFail),
+	}
+}
+
+func RemoveFromChannel(slack client.Slack, cache cache.Cache) flyte.Command {
+	return flyte.Command{
+		Name:         "RemoveFromChannel",
+		OutputEvents: []flyte.EventDef{removeFromChannelSuccessEventDef, removeFromChannelFailedEventDef},
+		Handler:      channelMembersHandler(slack, cache, slack.RemoveFromChannel, newRemoveFromChannelSuccess, newRemoveFromChannelFail),
+	}
+}
+
+type channelMembersFunc func(channelId string, userIds []string) ([]string, []client.UserFailure, error)
+
+type channelMembersSuccessFunc func(input ChannelMembersInput, done []string, failed []client.UserFailure) flyte.Event
+
+type channelMembersFailFunc func(input ChannelMembersInput, done []string, failed []client.UserFailure, reason string) flyte.Event
+
+// channelMembersHandler is shared by InviteToChannel and RemoveFromChannel. Users that can't be resolved
+// are reported as failed along with the ones slack refused, also when the channel fails after some users were handled.
+func channelMembersHandler(slack client.Slack, cache cache.Cache, update channelMembersFunc, success channelMembersSuccessFunc, fail channelMembersFailFunc) func(json.RawMessage) flyte.Event {
+	return func(rawInput json.RawMessage) flyte.Event {
+		input := ChannelMembersInput{}
+		if err := json.Unmarshal(rawInput, &input); err != nil {
+			return flyte.NewFatalEvent(fmt.Sprintf("input is not valid: %v", err))
+		}
+
+		errorMessages := []string{}
+		if input.ChannelId == "" {
+			errorMessages = append(errorMessages, "missing channel id field")
+		}
+		if len(input.UserIds) == 0 && len(input.Emails) == 0 && len(input.UserGroups) == 0 {
+			errorMessages = append(errorMessages, "missing user ids, emails or user groups field")
+		}
+		if len(errorMessages) != 0 {
+			return fail(input, nil, nil, strings.Join(errorMessages, ", "))
+		}
+
+		userIds, failed := resolveUserIds(slack, cache, input.UserIds, input.Emails, input.UserGroups)
+		done := []string{}
+		if len(userIds) != 0 {
+			var updateFailed []client.UserFailure
+			var err error
+			done, updateFailed, err = update(input.ChannelId, userIds)
+			failed = append(failed, updateFailed...)
+			if err != nil {
+				return fail(input, done, failed, err.Error())
+			}
+		}
+
+		return success(input, done, failed)
+	}
+}
+
+// resolveUserIds expands emails and user groups into user ids, keeping order and dropping duplicates
+func resolveUserIds(slack client.Slack, cache cache.Cache, userIds, emails, userGroups []string) ([]string, []client.UserFailure) {
+	ids := []string{}
+	failed := []client.UserFailure{}
+	seen := map[string]bool{}
+	add := func(id string) {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	for _, id := range userIds {
+		add(id)
+	}
+
+	for _, email := range emails {
+		u, err := slack.LookupUserByEmail(email)
+		if err != nil {
+			failed = append(failed, client.UserFailure{User: email, Reason: err.Error()})
+			continue
+		}
+		add(u.Id)
+	}
+
+	for _, handle := range userGroups {
+		g, err := cache.GetUserGroup(strings.TrimPrefix(handle, "@"), slack)
+		if err != nil {
+			failed = append(failed, client.UserFailure{User: handle, Reason: err.Error()})
+			continue
+		}
+		members, err := slack.GetUserGroupMembers(g.ID)
+		if err != nil {
+			failed = append(failed, client.UserFailure{User: handle, Reason: err.Error()})
+			continue
+		}
+		for _, id := range members {
+			add(id)
+		}
+	}
+
+	return ids, failed
+}
+
+func newInviteToChannelSuccess(input ChannelMembersInput, invited []string, failed []client.UserFailure) flyte.Event {
+	return flyte.Event{
+		EventDef: inviteToChannelSuccessEventDef,
+		Payload: InviteToChannelSuccess{
+			ChannelMembersInput: input,
+			Invited:             invited,
+			Failed:              failed,
+		},
+	}
+}
+
+func newRemoveFromChannelSuccess(input ChannelMembersInput, removed []string, failed []client.UserFailure) flyte.Event {
+	return flyte.Event{
+		EventDef: removeFromChannelSuccessEventDef,
+		Payload: RemoveFromChannelSuccess{
+			ChannelMembersInput: input,
+			Removed:             removed,
+			Failed:              failed,
+		},
+	}
+}
+
+func newInviteToChannelFail(input ChannelMembersInput, invited []string, failed []client.UserFailure, reason string) flyte.Event {
+	return flyte.Event{
+		EventDef: inviteToChannelFailedEventDef,
+		Payload: InviteToChannelFail{
+			ChannelMembersInput: input,
+			Invited:             invited,
+			Failed:              failed,
+			Reason:              reason,
+		},
+	}
+}
+
+func newRemoveFromChannelFail(input ChannelMembersInput, removed []string, failed []client.UserFailure, reason string) flyte.Event {
+	return flyte.Event{
+		EventDef: removeFromChannelFailedEventDef,
+		Payload: RemoveFromChannelFail{
+			ChannelMembersInput: input,
+			Removed:             removed,
+			Failed:              failed,
+			Reason:              reason,
+		},
+	}
+}
@@ -0,0 +1,134 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"errors"
+	"github.com/ExpediaGroup/flyte-slack/cache"
+	"github.com/ExpediaGroup/flyte-slack/client"
+	"github.com/ExpediaGroup/flyte-slack/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func newChannelMembersMockSlack() *MockSlack {
+	m := NewMockSlack()
+	m.LookupUserByEmailFunc = func(email string) (*client.User, error) {
+		if email == "alice@example.com" {
+			return &client.User{Id: "U-alice"}, nil
+		}
+		return nil, errors.New("users_not_found")
+	}
+	m.GetUserGroupsFunc = func() ([]types.UserGroup, error) {
+		return []types.UserGroup{{ID: "S1", Handle: "sre-oncall"}}, nil
+	}
+	m.GetUserGroupMembersFunc = func(userGroupId string) ([]string, error) {
+		return []string{"U-bob", "U-alice"}, nil
+	}
+	return m
+}
+
+func newChannelMembersCache() cache.Cache {
+	return cache.New(&cache.Config{RenewConversationListFrequency: time.Hour})
+}
+
+func TestInviteToChannelResolvesUsersAndReportsFailures(t *testing.T) {
+	slack := newChannelMembersMockSlack()
+	slack.InviteToChannelFunc = func(channelId string, userIds []string) ([]string, []client.UserFailure, error) {
+		assert.Equal(t, "C123", channelId)
+		assert.Equal(t, []string{"U1", "U-alice", "U-bob"}, userIds)
+		return []string{"U-alice", "U-bob"}, []client.UserFailure{{User: "U1", Reason: "already_in_channel"}}, nil
+	}
+
+	event := InviteToChannel(slack, newChannelMembersCache()).Handler([]byte(`{
+		"channelId": "C123",
+		"userIds": ["U1"],
+		"emails": ["alice@example.com", "nobody@example.com"],
+		"userGroups": ["@sre-oncall", "missing"]
+	}`))
+
+	require.Equal(t, inviteToChannelSuccessEventDef, event.EventDef)
+	output := event.Payload.(InviteToChannelSuccess)
+	assert.Equal(t, []string{"U-alice", "U-bob"}, output.Invited)
+	assert.Equal(t, []client.UserFailure{
+		{User: "nobody@example.com", Reason: "users_not_found"},
+		{User: "missing", Reason: "can't find user group with such handle"},
+		{User: "U1", Reason: "already_in_channel"},
+	}, output.Failed)
+}
+
+func TestInviteToChannelFails(t *testing.T) {
+	slack := newChannelMembersMockSlack()
+	slack.InviteToChannelFunc = func(channelId string, userIds []string) ([]string, []client.UserFailure, error) {
+		return nil, nil, errors.New("cannot invite users to channel=C123: channel_not_found")
+	}
+
+	tests := []struct {
+		name   string
+		input  string
+		reason string
+	}{
+		{name: "missing fields", input: `{}`, reason: "missing channel id field, missing user ids, emails or user groups field"},
+		{name: "channel error", input: `{"channelId": "C123", "userIds": ["U1"]}`, reason: "cannot invite users to channel=C123: channel_not_found"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			event := InviteToChannel(slack, newChannelMembersCache()).Handler([]byte(test.input))
+
+			require.Equal(t, inviteToChannelFailedEventDef, event.EventDef)
+			assert.Equal(t, test.reason, event.Payload.(InviteToChannelFail).Reason)
+		})
+	}
+}
+
+func TestInviteToChannelFailureReportsUsersHandledBeforeChannelError(t *testing.T) {
+	slack := newChannelMembersMockSlack()
+	slack.InviteToChannelFunc = func(channelId string, userIds []string) ([]string, []client.UserFailure, error) {
+		return []string{"U1"}, []client.UserFailure{{User: "U2", Reason: "already_in_channel"}}, errors.New("cannot invite users to channel=C123: is_archived")
+	}
+
+	event := InviteToChannel(slack, newChannelMembersCache()).Handler([]byte(`{
+		"channelId": "C123",
+		"userIds": ["U1", "U2", "U3"],
+		"emails": ["nobody@example.com"]
+	}`))
+
+	require.Equal(t, inviteToChannelFailedEventDef, event.EventDef)
+	output := event.Payload.(InviteToChannelFail)
+	assert.Equal(t, "cannot invite users to channel=C123: is_archived", output.Reason)
+	assert.Equal(t, []string{"U1"}, output.Invited)
+	assert.Equal(t, []client.UserFailure{
+		{User: "nobody@example.com", Reason: "users_not_found"},
+		{User: "U2", Reason: "already_in_channel"},
+	}, output.Failed)
+}
+
+func TestRemoveFromChannel(t *testing.T) {
+	slack := newChannelMembersMockSlack()
+	slack.RemoveFromChannelFunc = func(channelId string, userIds []string) ([]string, []client.UserFailure, error) {
+		assert.Equal(t, []string{"U-alice"}, userIds)
+		return userIds, []client.UserFailure{}, nil
+	}
+
+	event := RemoveFromChannel(slack, newChannelMembersCache()).Handler([]byte(`{"channelId": "C123", "emails": ["alice@example.com"]}`))
+
+	require.Equal(t, removeFromChannelSuccessEventDef, event.EventDef)
+	assert.Equal(t, []string{"U-alice"}, event.Payload.(RemoveFromChannelSuccess).Removed)
+}
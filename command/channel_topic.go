@@ -0,0 +1,162 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/ExpediaGroup/flyte-client/flyte"
+	"github.com/ExpediaGroup/flyte-slack/client"
+	"github.com/ExpediaGroup/flyte-slack/types"
+)
+
+var (
+	setChannelTopicSuccessEventDef   = flyte.EventDef{Name: "SetChannelTopicSuccess"}
+	setChannelTopicFailedEventDef    = flyte.EventDef{Name: "SetChannelTopicFailed"}
+	setChannelPurposeSuccessEventDef = flyte.EventDef{Name: "SetChannelPurposeSuccess"}
+	setChannelPurposeFailedEventDef  = flyte.EventDef{Name: "SetChannelPurposeFailed"}
+)
+
+type SetChannelTopicInput struct {
+	ChannelId string `json:"channelId"`
+	// Topic may contain mention placeholders, empty topic clears it
+	Topic string `json:"topic"`
+}
+
+type SetChannelTopicSuccess struct {
+	SetChannelTopicInput
+	Conversation *types.Conversation `json:"conversation"`
+}
+
+type SetChannelTopicFail struct {
+	SetChannelTopicInput
+	Reason string `json:"reason"`
+}
+
+type SetChannelPurposeInput struct {
+	ChannelId string `json:"channelId"`
+	// Purpose may contain mention placeholders, empty purpose clears it
+	Purpose string `json:"purpose"`
+}
+
+type SetChannelPurposeSuccess struct {
+	SetChannelPurposeInput
+	Conversation *types.Conversation `json:"conversation"`
+}
+
+type SetChannelPurposeFail struct {
+	SetChannelPurposeInput
+	Reason string `json:"reason"`
+}
+
+func SetChannelTopic(slack client.Slack, mentions MentionResolver) flyte.Command {
+	return flyte.Command{
+		Name:         "SetChannelTopic",
+		OutputEvents: []flyte.EventDef{setChannelTopicSuccessEventDef, setChannelTopicFailedEventDef},
+		Handler:      setChannelTopicHandler(slack, mentions),
+	}
+}
+
+func setChannelTopicHandler(slack client.Slack, mentions MentionResolver) func(json.RawMessage) flyte.Event {
+	return func(rawInput json.RawMessage) flyte.Event {
+		input := SetChannelTopicInput{}
+		if err := json.Unmarshal(rawInput, &input); err != nil {
+			return flyte.NewFatalEvent(fmt.Sprintf("input is not valid: %v", err))
+		}
+
+		if input.ChannelId == "" {
+			return newSetChannelTopicFail(input, "missing channel id field")
+		}
+
+		topic, err := resolveMentions(mentions, input.Topic)
+		if err != nil {
+			return newSetChannelTopicFail(input, err.Error())
+		}
+
+		conv, err := slack.SetChannelTopic(input.ChannelId, topic)
+		if err != nil {
+			return newSetChannelTopicFail(input, err.Error())
+		}
+
+		return flyte.Event{
+			EventDef: setChannelTopicSuccessEventDef,
+			Payload: SetChannelTopicSuccess{
+				SetChannelTopicInput: input,
+				Conversation:         conv,
+			},
+		}
+	}
+}
+
+func newSetChannelTopicFail(input SetChannelTopicInput, reason string) flyte.Event {
+	return flyte.Event{
+		EventDef: setChannelTopicFailedEventDef,
+		Payload: SetChannelTopicFail{
+			SetChannelTopicInput: input,
+			Reason:               reason,
+		},
+	}
+}
+
+func SetChannelPurpose(slack client.Slack, mentions MentionResolver) flyte.Command {
+	return flyte.Command{
+		Name:         "SetChannelPurpose",
+		OutputEvents: []flyte.EventDef{setChannelPurposeSuccessEventDef, setChannelPurposeFailedEventDef},
+		Handler:      setChannelPurposeHandler(slack, mentions),
+	}
+}
+
+func setChannelPurposeHandler(slack client.Slack, mentions MentionResolver) func(json.RawMessage) flyte.Event {
+	return func(rawInput json.RawMessage) flyte.Event {
+		input := SetChannelPurposeInput{}
+		if err := json.Unmarshal(rawInput, &input); err != nil {
+			return flyte.NewFatalEvent(fmt.Sprintf("input is not valid: %v", err))
+		}
+
+		if input.ChannelId == "" {
+			return newSetChannelPurposeFail(input, "missing channel id field")
+		}
+
+		purpose, err := resolveMentions(mentions, input.Purpose)
+		if err != nil {
+			return newSetChannelPurposeFail(input, err.Error())
+		}
+
+		conv, err := slack.SetChannelPurpose(input.ChannelId, purpose)
+		if err != nil {
+			return newSetChannelPurposeFail(input, err.Error())
+		}
+
+		return flyte.Event{
+			EventDef: setChannelPurposeSuccessEventDef,
+			Payload: SetChannelPurposeSuccess{
+				SetChannelPurposeInput: input,
+				Conversation:           conv,
+			},
+		}
+	}
+}
+
+func newSetChannelPurposeFail(input SetChannelPurposeInput, reason string) flyte.Event {
+	return flyte.Event{
+		EventDef: setChannelPurposeFailedEventDef,
+		Payload: SetChannelPurposeFail{
+			SetChannelPurposeInput: input,
+			Reason:                 reason,
+		},
+	}
+}
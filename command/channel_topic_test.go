@@ -0,0 +1,71 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"errors"
+	"github.com/ExpediaGroup/flyte-slack/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestSetChannelTopicResolvesMentions(t *testing.T) {
+	slack := newMentionMockSlack()
+	slack.SetChannelTopicFunc = func(channelId, topic string) (*types.Conversation, error) {
+		assert.Equal(t, "C123", channelId)
+		return &types.Conversation{ID: channelId, Name: "sre", Topic: topic}, nil
+	}
+
+	event := SetChannelTopic(slack, newTestMentionResolver(slack, false)).Handler([]byte(`{"channelId": "C123", "topic": "On call: @{user:jdoe}"}`))
+
+	require.Equal(t, setChannelTopicSuccessEventDef, event.EventDef)
+	output := event.Payload.(SetChannelTopicSuccess)
+	assert.Equal(t, "On call: @{user:jdoe}", output.Topic)
+	assert.Equal(t, "On call: <@U2>", output.Conversation.Topic)
+}
+
+func TestSetChannelTopicFailsWithoutChannelId(t *testing.T) {
+	event := SetChannelTopic(NewMockSlack(), nil).Handler([]byte(`{"topic": "On call: jdoe"}`))
+
+	require.Equal(t, setChannelTopicFailedEventDef, event.EventDef)
+	assert.Equal(t, "missing channel id field", event.Payload.(SetChannelTopicFail).Reason)
+}
+
+func TestSetChannelPurpose(t *testing.T) {
+	slack := NewMockSlack()
+	slack.SetChannelPurposeFunc = func(channelId, purpose string) (*types.Conversation, error) {
+		return &types.Conversation{ID: channelId, Purpose: purpose}, nil
+	}
+
+	event := SetChannelPurpose(slack, nil).Handler([]byte(`{"channelId": "C123", "purpose": "SRE team chat"}`))
+
+	require.Equal(t, setChannelPurposeSuccessEventDef, event.EventDef)
+	assert.Equal(t, "SRE team chat", event.Payload.(SetChannelPurposeSuccess).Conversation.Purpose)
+}
+
+func TestSetChannelPurposeFails(t *testing.T) {
+	slack := NewMockSlack()
+	slack.SetChannelPurposeFunc = func(channelId, purpose string) (*types.Conversation, error) {
+		return nil, errors.New("cannot set purpose of channel=C123: not_in_channel")
+	}
+
+	event := SetChannelPurpose(slack, nil).Handler([]byte(`{"channelId": "C123", "purpose": "SRE team chat"}`))
+
+	require.Equal(t, setChannelPurposeFailedEventDef, event.EventDef)
+	assert.Equal(t, "cannot set purpose of channel=C123: not_in_channel", event.Payload.(SetChannelPurposeFail).Reason)
+}
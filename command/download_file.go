@@ -0,0 +1,112 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/ExpediaGroup/flyte-client/flyte"
+	"github.com/ExpediaGroup/flyte-slack/client"
+	"unicode/utf8"
+)
+
+var (
+	fileDownloadedEventDef     = flyte.EventDef{Name: "FileDownloaded"}
+	downloadFileFailedEventDef = flyte.EventDef{Name: "DownloadFileFailed"}
+)
+
+type DownloadFileInput struct {
+	FileId string `json:"fileId"`
+	// ContentEncoding is either "base64" (default) or "text"
+	ContentEncoding string `json:"contentEncoding"`
+}
+
+type DownloadFileSuccess struct {
+	DownloadFileInput
+	Name     string `json:"name"`
+	Mimetype string `json:"mimetype"`
+	Size     int    `json:"size"`
+	Content  string `json:"content"`
+}
+
+type DownloadFileFail struct {
+	DownloadFileInput
+	Reason string `json:"reason"`
+}
+
+// DownloadFile fetches private file shared in slack, files over maxSize bytes are refused
+func DownloadFile(slack client.Slack, maxSize int) flyte.Command {
+	return flyte.Command{
+		Name:         "DownloadFile",
+		OutputEvents: []flyte.EventDef{fileDownloadedEventDef, downloadFileFailedEventDef},
+		Handler:      downloadFileHandler(slack, maxSize),
+	}
+}
+
+func downloadFileHandler(slack client.Slack, maxSize int) func(json.RawMessage) flyte.Event {
+	return func(rawInput json.RawMessage) flyte.Event {
+		input := DownloadFileInput{}
+		if err := json.Unmarshal(rawInput, &input); err != nil {
+			return flyte.NewFatalEvent(fmt.Sprintf("input is not valid: %v", err))
+		}
+
+		if input.FileId == "" {
+			return newDownloadFileFail(input, "missing file id field")
+		}
+		if input.ContentEncoding == "" {
+			input.ContentEncoding = contentEncodingBase64
+		}
+		if input.ContentEncoding != contentEncodingBase64 && input.ContentEncoding != contentEncodingText {
+			return newDownloadFileFail(input, fmt.Sprintf("unknown content encoding=%q, expected %q or %q", input.ContentEncoding, contentEncodingBase64, contentEncodingText))
+		}
+
+		f, err := slack.DownloadFile(input.FileId, maxSize)
+		if err != nil {
+			return newDownloadFileFail(input, err.Error())
+		}
+
+		content := base64.StdEncoding.EncodeToString(f.Content)
+		if input.ContentEncoding == contentEncodingText {
+			if !utf8.Valid(f.Content) {
+				return newDownloadFileFail(input, fmt.Sprintf("file=%s is not valid utf-8 text, use %q content encoding", input.FileId, contentEncodingBase64))
+			}
+			content = string(f.Content)
+		}
+
+		return flyte.Event{
+			EventDef: fileDownloadedEventDef,
+			Payload: DownloadFileSuccess{
+				DownloadFileInput: input,
+				Name:              f.Name,
+				Mimetype:          f.Mimetype,
+				Size:              f.Size,
+				Content:           content,
+			},
+		}
+	}
+}
+
+func newDownloadFileFail(input DownloadFileInput, reason string) flyte.Event {
+	return flyte.Event{
+		EventDef: downloadFileFailedEventDef,
+		Payload: DownloadFileFail{
+			DownloadFileInput: input,
+			Reason:            reason,
+		},
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"errors"
+	"github.com/ExpediaGroup/flyte-slack/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestDownloadFileCommandIsPopulated(t *testing.T) {
+	command := DownloadFile(nil, 0)
+
+	assert.Equal(t, "DownloadFile", command.Name)
+	require.Equal(t, 2, len(command.OutputEvents))
+	assert.Equal(t, "FileDownloaded", command.OutputEvents[0].Name)
+	assert.Equal(t, "DownloadFileFailed", command.OutputEvents[1].Name)
+}
+
+func TestDownloadFileReturnsContent(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		content string
+	}{
+		{name: "default base64", input: `{"fileId": "F123"}`, content: "c3RhY2sgdHJhY2U="},
+		{name: "text", input: `{"fileId": "F123", "contentEncoding": "text"}`, content: "stack trace"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			slack := NewMockSlack()
+			slack.DownloadFileFunc = func(fileId string, maxSize int) (*client.DownloadedFile, error) {
+				assert.Equal(t, "F123", fileId)
+				assert.Equal(t, 1024, maxSize)
+				return &client.DownloadedFile{ID: fileId, Name: "trace.log", Mimetype: "text/plain", Size: 11, Content: []byte("stack trace")}, nil
+			}
+
+			event := DownloadFile(slack, 1024).Handler([]byte(test.input))
+
+			require.Equal(t, fileDownloadedEventDef, event.EventDef)
+			output := event.Payload.(DownloadFileSuccess)
+			assert.Equal(t, test.content, output.Content)
+			assert.Equal(t, "trace.log", output.Name)
+			assert.Equal(t, "text/plain", output.Mimetype)
+			assert.Equal(t, 11, output.Size)
+		})
+	}
+}
+
+func TestDownloadFileFails(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		err    error
+		reason string
+	}{
+		{name: "missing file id", input: `{}`, reason: "missing file id field"},
+		{name: "unknown encoding", input: `{"fileId": "F123", "contentEncoding": "hex"}`, reason: `unknown content encoding="hex", expected "base64" or "text"`},
+		{name: "binary as text", input: `{"fileId": "F123", "contentEncoding": "text"}`, reason: `file=F123 is not valid utf-8 text, use "base64" content encoding`},
+		{name: "slack error", input: `{"fileId": "F123"}`, err: errors.New("file=F123 has 2048 bytes, over download limit of 1024 bytes"), reason: "file=F123 has 2048 bytes, over download limit of 1024 bytes"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			slack := NewMockSlack()
+			slack.DownloadFileFunc = func(fileId string, maxSize int) (*client.DownloadedFile, error) {
+				if test.err != nil {
+					return nil, test.err
+				}
+				return &client.DownloadedFile{ID: fileId, Content: []byte{0xff, 0xfe}}, nil
+			}
+
+			event := DownloadFile(slack, 1024).Handler([]byte(test.input))
+
+			require.Equal(t, downloadFileFailedEventDef, event.EventDef)
+			assert.Equal(t, test.reason, event.Payload.(DownloadFileFail).Reason)
+		})
+	}
+}
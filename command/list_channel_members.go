@@ -0,0 +1,130 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/ExpediaGroup/flyte-client/flyte"
+	"github.com/ExpediaGroup/flyte-slack/cache"
+	"github.com/ExpediaGroup/flyte-slack/client"
+)
+
+var (
+	listChannelMembersSuccessEventDef = flyte.EventDef{Name: "ListChannelMembersSuccess"}
+	listChannelMembersFailedEventDef  = flyte.EventDef{Name: "ListChannelMembersFailed"}
+)
+
+type ListChannelMembersInput struct {
+	ChannelInput
+	// ExpandUsers adds user details of each member, it's implied by the exclude filters
+	ExpandUsers    bool `json:"expandUsers"`
+	ExcludeBots    bool `json:"excludeBots"`
+	ExcludeDeleted bool `json:"excludeDeleted"`
+}
+
+type ListChannelMembersSuccess struct {
+	ListChannelMembersInput
+	MemberIds []string      `json:"memberIds"`
+	Members   []client.User `json:"members,omitempty"`
+	// Failed lists members that couldn't be looked up, they're kept in MemberIds
+	Failed []client.UserFailure `json:"failed,omitempty"`
+}
+
+type ListChannelMembersFail struct {
+	ListChannelMembersInput
+	Reason string `json:"reason"`
+}
+
+func ListChannelMembers(slack client.Slack, cache cache.Cache) flyte.Command {
+	return flyte.Command{
+		Name:         "ListChannelMembers",
+		OutputEvents: []flyte.EventDef{listChannelMembersSuccessEventDef, listChannelMembersFailedEventDef},
+		Handler:      listChannelMembersHandler(slack, cache),
+	}
+}
+
+func listChannelMembersHandler(slack client.Slack, cache cache.Cache) func(json.RawMessage) flyte.Event {
+	return func(rawInput json.RawMessage) flyte.Event {
+		input := ListChannelMembersInput{}
+		if err := json.Unmarshal(rawInput, &input); err != nil {
+			return flyte.NewFatalEvent(fmt.Sprintf("input is not valid: %v", err))
+		}
+
+		if err := resolveChannelId(&input.ChannelInput, slack, cache); err != nil {
+			return newListChannelMembersFail(input, err.Error())
+		}
+
+		ids, err := slack.ListChannelMembers(input.ChannelId)
+		if err != nil {
+			return newListChannelMembersFail(input, err.Error())
+		}
+
+		if !input.ExpandUsers && !input.ExcludeBots && !input.ExcludeDeleted {
+			return newListChannelMembersSuccess(input, ids, nil, nil)
+		}
+
+		users, failed := slack.GetUsersInfo(ids)
+		byId := map[string]client.User{}
+		for _, u := range users {
+			byId[u.Id] = u
+		}
+
+		memberIds := []string{}
+		members := []client.User{}
+		for _, id := range ids {
+			u, ok := byId[id]
+			if !ok {
+				// members that can't be looked up are kept, it's not known whether they should be filtered out
+				memberIds = append(memberIds, id)
+				continue
+			}
+			if (input.ExcludeBots && u.IsBot) || (input.ExcludeDeleted && u.Deleted) {
+				continue
+			}
+			memberIds = append(memberIds, u.Id)
+			members = append(members, u)
+		}
+
+		if !input.ExpandUsers {
+			members = nil
+		}
+		return newListChannelMembersSuccess(input, memberIds, members, failed)
+	}
+}
+
+func newListChannelMembersSuccess(input ListChannelMembersInput, memberIds []string, members []client.User, failed []client.UserFailure) flyte.Event {
+	return flyte.Event{
+		EventDef: listChannelMembersSuccessEventDef,
+		Payload: ListChannelMembersSuccess{
+			ListChannelMembersInput: input,
+			MemberIds:               memberIds,
+			Members:                 members,
+			Failed:                  failed,
+		},
+	}
+}
+
+func newListChannelMembersFail(input ListChannelMembersInput, reason string) flyte.Event {
+	return flyte.Event{
+		EventDef: listChannelMembersFailedEventDef,
+		Payload: ListChannelMembersFail{
+			ListChannelMembersInput: input,
+			Reason:                  reason,
+		},
+	}
+}
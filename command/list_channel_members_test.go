@@ -0,0 +1,106 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"errors"
+	"github.com/ExpediaGroup/flyte-slack/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func newListChannelMembersMockSlack() *MockSlack {
+	slack := NewMockSlack()
+	slack.ListChannelMembersFunc = func(channelId string) ([]string, error) {
+		if channelId != "C123" {
+			return nil, errors.New("cannot list members of channel=" + channelId + ": channel_not_found")
+		}
+		return []string{"U1", "UBOT", "U2"}, nil
+	}
+	slack.GetUsersInfoFunc = func(userIds []string) ([]client.User, []client.UserFailure) {
+		users := []client.User{}
+		failed := []client.UserFailure{}
+		for _, id := range userIds {
+			switch id {
+			case "UBOT":
+				users = append(users, client.User{Id: id, Name: "flyte", IsBot: true})
+			case "U2":
+				users = append(users, client.User{Id: id, Name: "leaver", Deleted: true})
+			case "U404":
+				failed = append(failed, client.UserFailure{User: id, Reason: "cannot get info about user=U404: user_not_found"})
+			default:
+				users = append(users, client.User{Id: id, Name: "jdoe"})
+			}
+		}
+		return users, failed
+	}
+	return slack
+}
+
+func TestListChannelMembers(t *testing.T) {
+	slack := newListChannelMembersMockSlack()
+	slack.GetUsersInfoFunc = nil
+
+	event := ListChannelMembers(slack, newTestCache()).Handler([]byte(`{"channelId": "C123"}`))
+
+	require.Equal(t, listChannelMembersSuccessEventDef, event.EventDef)
+	output := event.Payload.(ListChannelMembersSuccess)
+	assert.Equal(t, []string{"U1", "UBOT", "U2"}, output.MemberIds)
+	assert.Nil(t, output.Members)
+}
+
+func TestListChannelMembersExcludesBotsAndDeletedUsers(t *testing.T) {
+	event := ListChannelMembers(newListChannelMembersMockSlack(), newTestCache()).
+		Handler([]byte(`{"channelId": "C123", "expandUsers": true, "excludeBots": true, "excludeDeleted": true}`))
+
+	require.Equal(t, listChannelMembersSuccessEventDef, event.EventDef)
+	output := event.Payload.(ListChannelMembersSuccess)
+	assert.Equal(t, []string{"U1"}, output.MemberIds)
+	assert.Equal(t, []client.User{{Id: "U1", Name: "jdoe"}}, output.Members)
+}
+
+func TestListChannelMembersFiltersWithoutExpanding(t *testing.T) {
+	event := ListChannelMembers(newListChannelMembersMockSlack(), newTestCache()).Handler([]byte(`{"channelId": "C123", "excludeBots": true}`))
+
+	require.Equal(t, listChannelMembersSuccessEventDef, event.EventDef)
+	output := event.Payload.(ListChannelMembersSuccess)
+	assert.Equal(t, []string{"U1", "U2"}, output.MemberIds)
+	assert.Nil(t, output.Members)
+}
+
+func TestListChannelMembersReportsMembersThatCannotBeLookedUp(t *testing.T) {
+	slack := newListChannelMembersMockSlack()
+	slack.ListChannelMembersFunc = func(channelId string) ([]string, error) {
+		return []string{"U404", "U1", "UBOT"}, nil
+	}
+
+	event := ListChannelMembers(slack, newTestCache()).Handler([]byte(`{"channelId": "C123", "expandUsers": true, "excludeBots": true}`))
+
+	require.Equal(t, listChannelMembersSuccessEventDef, event.EventDef)
+	output := event.Payload.(ListChannelMembersSuccess)
+	assert.Equal(t, []string{"U404", "U1"}, output.MemberIds, "members keep their order")
+	assert.Equal(t, []client.User{{Id: "U1", Name: "jdoe"}}, output.Members)
+	assert.Equal(t, []client.UserFailure{{User: "U404", Reason: "cannot get info about user=U404: user_not_found"}}, output.Failed)
+}
+
+func TestListChannelMembersFails(t *testing.T) {
+	event := ListChannelMembers(newListChannelMembersMockSlack(), newTestCache()).Handler([]byte(`{"channelId": "C404"}`))
+
+	require.Equal(t, listChannelMembersFailedEventDef, event.EventDef)
+	assert.Equal(t, "cannot list members of channel=C404: channel_not_found", event.Payload.(ListChannelMembersFail).Reason)
+}
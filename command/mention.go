@@ -0,0 +1,141 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"fmt"
+	"github.com/ExpediaGroup/flyte-slack/cache"
+	"github.com/ExpediaGroup/flyte-slack/client"
+	"github.com/slack-go/slack"
+	"regexp"
+	"strings"
+)
+
+// mentionRegexp matches placeholders such as @{email:jane@corp.com}, @{user:jdoe}, @{group:sre-oncall} and #{channel:incidents}
+var mentionRegexp = regexp.MustCompile(`@\{(email|user|group):([^}\s]+)\}|#\{(channel):([^}\s]+)\}`)
+
+// MentionResolver replaces mention placeholders in outgoing text with slack mention syntax
+type MentionResolver interface {
+	ResolveMentions(text string) (string, error)
+}
+
+type mentionResolver struct {
+	slack client.Slack
+	cache cache.Cache
+	// degrade replaces unresolvable placeholders with plain text instead of failing
+	degrade bool
+}
+
+// NewMentionResolver looks users up through the slack user cache and everything else through cache.
+// When degrade is set, placeholders that can't be resolved are rendered as plain text, e.g. "@jdoe".
+func NewMentionResolver(slack client.Slack, cache cache.Cache, degrade bool) MentionResolver {
+	return &mentionResolver{slack: slack, cache: cache, degrade: degrade}
+}
+
+func (r *mentionResolver) ResolveMentions(text string) (string, error) {
+	var unresolved []string
+	out := mentionRegexp.ReplaceAllStringFunc(text, func(placeholder string) string {
+		m := mentionRegexp.FindStringSubmatch(placeholder)
+		kind, value, sigil := m[1], m[2], "@"
+		if kind == "" {
+			kind, value, sigil = m[3], m[4], "#"
+		}
+
+		mention, err := r.resolve(kind, value)
+		if err != nil {
+			unresolved = append(unresolved, fmt.Sprintf("%s: %v", placeholder, err))
+			return sigil + value
+		}
+		return mention
+	})
+
+	if len(unresolved) != 0 && !r.degrade {
+		return "", fmt.Errorf("cannot resolve mentions: %s", strings.Join(unresolved, ", "))
+	}
+	return out, nil
+}
+
+func (r *mentionResolver) resolve(kind, value string) (string, error) {
+	switch kind {
+	case "email":
+		u, err := r.slack.LookupUserByEmail(value)
+		if err != nil {
+			return "", err
+		}
+		return "<@" + u.Id + ">", nil
+	case "user":
+		u, err := r.slack.LookupUserByName(value)
+		if err != nil {
+			return "", err
+		}
+		return "<@" + u.Id + ">", nil
+	case "group":
+		g, err := r.cache.GetUserGroup(value, r.slack)
+		if err != nil {
+			return "", err
+		}
+		return "<!subteam^" + g.ID + ">", nil
+	default:
+		c, err := r.cache.GetChannelID(value, r.slack)
+		if err != nil {
+			return "", err
+		}
+		return "<#" + c.ID + "|" + c.Name + ">", nil
+	}
+}
+
+// resolveMentions is a no-op without resolver
+func resolveMentions(mentions MentionResolver, text string) (string, error) {
+	if mentions == nil || text == "" {
+		return text, nil
+	}
+	return mentions.ResolveMentions(text)
+}
+
+// resolveRichMessageMentions resolves mentions in text and attachments of the message,
+// attachments are copied so that input stays as it was
+func resolveRichMessageMentions(mentions MentionResolver, rm client.RichMessage) (client.RichMessage, error) {
+	var err error
+	if rm.Text, err = resolveMentions(mentions, rm.Text); err != nil {
+		return rm, err
+	}
+	if rm.Attachments == nil {
+		return rm, nil
+	}
+
+	attachments := make([]slack.Attachment, len(rm.Attachments))
+	for i, a := range rm.Attachments {
+		if a.Pretext, err = resolveMentions(mentions, a.Pretext); err != nil {
+			return rm, err
+		}
+		if a.Text, err = resolveMentions(mentions, a.Text); err != nil {
+			return rm, err
+		}
+		fields := make([]slack.AttachmentField, len(a.Fields))
+		for j, f := range a.Fields {
+			if f.Value, err = resolveMentions(mentions, f.Value); err != nil {
+				return rm, err
+			}
+			fields[j] = f
+		}
+		a.Fields = fields
+		attachments[i] = a
+	}
+	rm.Attachments = attachments
+
+	return rm, nil
+}
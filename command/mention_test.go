@@ -0,0 +1,121 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"errors"
+	"github.com/ExpediaGroup/flyte-slack/cache"
+	"github.com/ExpediaGroup/flyte-slack/client"
+	"github.com/ExpediaGroup/flyte-slack/types"
+	"github.com/slack-go/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func newMentionMockSlack() *MockSlack {
+	m := NewMockSlack()
+	m.LookupUserByEmailFunc = func(email string) (*client.User, error) {
+		if email == "jane@corp.com" {
+			return &client.User{Id: "U1", Name: "jane"}, nil
+		}
+		return nil, errors.New("users_not_found")
+	}
+	m.LookupUserByNameFunc = func(name string) (*client.User, error) {
+		if name == "jdoe" || name == "John" {
+			return &client.User{Id: "U2", Name: "jdoe"}, nil
+		}
+		return nil, errors.New("can't find user with such name")
+	}
+	m.GetUserGroupsFunc = func() ([]types.UserGroup, error) {
+		return []types.UserGroup{{ID: "S1", Handle: "sre-oncall"}}, nil
+	}
+	m.GetConversationsFunc = func() ([]types.Conversation, error) {
+		return []types.Conversation{{ID: "C1", Name: "incidents"}}, nil
+	}
+	return m
+}
+
+func newTestMentionResolver(slack client.Slack, degrade bool) MentionResolver {
+	return NewMentionResolver(slack, cache.New(&cache.Config{RenewConversationListFrequency: time.Hour}), degrade)
+}
+
+func TestResolveMentions(t *testing.T) {
+	r := newTestMentionResolver(newMentionMockSlack(), false)
+
+	out, err := r.ResolveMentions("@{email:jane@corp.com}, @{user:jdoe} and @{user:John} from @{group:sre-oncall} see #{channel:incidents}")
+
+	require.NoError(t, err)
+	assert.Equal(t, "<@U1>, <@U2> and <@U2> from <!subteam^S1> see <#C1|incidents>", out)
+}
+
+func TestResolveMentionsFailsOnUnresolvablePlaceholders(t *testing.T) {
+	r := newTestMentionResolver(newMentionMockSlack(), false)
+
+	_, err := r.ResolveMentions("@{email:bob@corp.com} @{user:jdoe} #{channel:nope}")
+
+	require.Error(t, err)
+	assert.Equal(t, "cannot resolve mentions: @{email:bob@corp.com}: users_not_found, #{channel:nope}: can't find channel with such name", err.Error())
+}
+
+func TestResolveMentionsDegradesToPlainText(t *testing.T) {
+	r := newTestMentionResolver(newMentionMockSlack(), true)
+
+	out, err := r.ResolveMentions("@{email:bob@corp.com} @{group:nope} #{channel:nope} @{user:jdoe}")
+
+	require.NoError(t, err)
+	assert.Equal(t, "@bob@corp.com @nope #nope <@U2>", out)
+}
+
+func TestSendMessageResolvesMentions(t *testing.T) {
+	BeforeMessage()
+	r := newTestMentionResolver(newMentionMockSlack(), false)
+
+	event := SendMessage(MessageMockSlack, r).Handler([]byte(`{"message": "ping @{user:jdoe}", "channelId": "abc-channel"}`))
+
+	assert.Equal(t, "MessageSent", event.EventDef.Name)
+	assert.Equal(t, "ping <@U2>", MessageMockSlack.SendMessageCalls["abc-channel"][0])
+}
+
+func TestSendRichMessageResolvesMentions(t *testing.T) {
+	var sent client.RichMessage
+	mp := mockRichMessageSender{
+		sendRichMessage: func(rm client.RichMessage) (string, string, error) {
+			sent = rm
+			return "", "", nil
+		},
+	}
+	r := newTestMentionResolver(newMentionMockSlack(), false)
+
+	event := SendRichMessage(mp, r).Handler([]byte(`{"channel": "C1", "text": "@{group:sre-oncall}", "attachments": [{"fields": [{"value": "@{user:jdoe}"}]}]}`))
+
+	assert.Equal(t, richMessageSentEventDef, event.EventDef)
+	assert.Equal(t, "<!subteam^S1>", sent.Text)
+	assert.Equal(t, []slack.AttachmentField{{Value: "<@U2>"}}, sent.Attachments[0].Fields)
+}
+
+func TestSendRichMessageFailsOnUnresolvableMentions(t *testing.T) {
+	r := newTestMentionResolver(newMentionMockSlack(), false)
+
+	event := SendRichMessage(mockRichMessageSender{}, r).Handler([]byte(`{"channel": "C1", "text": "@{user:nope}"}`))
+
+	require.Equal(t, sendRichMessageFailedEventDef, event.EventDef)
+	output := event.Payload.(SendRichMessageErrorOutput)
+	assert.Equal(t, "@{user:nope}", output.InputMessage.Text)
+	assert.Equal(t, "cannot resolve mentions: @{user:nope}: can't find user with such name", output.Error)
+}
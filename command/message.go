@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"github.com/ExpediaGroup/flyte-client/flyte"
 	"github.com/ExpediaGroup/flyte-slack/client"
+	"github.com/rs/zerolog/log"
 	"strings"
 )
 
@@ -33,10 +34,14 @@ type SendMessageInput struct {
 	Message         string `json:"message"`
 	ThreadTimestamp string `json:"threadTimestamp"`
 	ChannelId       string `json:"channelId"`
+	// Format is either "mrkdwn" (default) or "markdown"
+	Format string `json:"format,omitempty"`
 }
 
 type SendMessageOutput struct {
 	SendMessageInput
+	// Permalink is only set in MessageSent when slack reports timestamp of the message
+	Permalink string `json:"permalink,omitempty"`
 }
 
 type SendMessageErrorOutput struct {
@@ -44,16 +49,17 @@ type SendMessageErrorOutput struct {
 	Error string `json:"error"`
 }
 
-func SendMessage(slack client.Slack) flyte.Command {
+// SendMessage sends message resolving mention placeholders in it, mentions can be nil
+func SendMessage(slack client.Slack, mentions MentionResolver) flyte.Command {
 
 	return flyte.Command{
 		Name:         "SendMessage",
 		OutputEvents: []flyte.EventDef{messageSentEventDef, sendMessageFailedEventDef},
-		Handler:      sendMessageHandler(slack),
+		Handler:      sendMessageHandler(slack, mentions),
 	}
 }
 
-func sendMessageHandler(slack client.Slack) func(json.RawMessage) flyte.Event {
+func sendMessageHandler(slack client.Slack, mentions MentionResolver) func(json.RawMessage) flyte.Event {
 
 	return func(rawInput json.RawMessage) flyte.Event {
 
@@ -69,20 +75,44 @@ func sendMessageHandler(slack client.Slack) func(json.RawMessage) flyte.Event {
 		if input.ChannelId == "" {
 			errorMessages = append(errorMessages, "missing channel id field")
 		}
+		text, err := client.FormatText(input.Message, input.Format)
+		if err != nil {
+			errorMessages = append(errorMessages, err.Error())
+		}
 		if len(errorMessages) != 0 {
 			return newSendMessageFailedEvent(input.Message, input.ChannelId, strings.Join(errorMessages, ", "))
 		}
 
-		slack.SendMessage(input.Message, input.ChannelId, input.ThreadTimestamp)
-		return newMessageSentEvent(input.Message, input.ChannelId)
+		if text, err = resolveMentions(mentions, text); err != nil {
+			return newSendMessageFailedEvent(input.Message, input.ChannelId, err.Error())
+		}
+
+		ts, err := slack.SendMessage(text, input.ChannelId, input.ThreadTimestamp)
+		if err != nil {
+			return newSendMessageFailedEvent(input.Message, input.ChannelId, err.Error())
+		}
+		return newMessageSentEvent(input.Message, input.ChannelId, sentMessagePermalink(slack, input.ChannelId, ts))
+	}
+}
+
+// sentMessagePermalink is best effort, message got sent even when permalink can't be fetched
+func sentMessagePermalink(slack PermalinkGetter, channelId, timestamp string) string {
+	if timestamp == "" {
+		return ""
+	}
+	p, err := slack.GetPermalink(channelId, timestamp)
+	if err != nil {
+		log.Err(err).Send()
+		return ""
 	}
+	return p
 }
 
-func newMessageSentEvent(message, channelId string) flyte.Event {
+func newMessageSentEvent(message, channelId, permalink string) flyte.Event {
 
 	return flyte.Event{
 		EventDef: messageSentEventDef,
-		Payload:  SendMessageOutput{SendMessageInput: SendMessageInput{Message: message, ChannelId: channelId}},
+		Payload:  SendMessageOutput{SendMessageInput: SendMessageInput{Message: message, ChannelId: channelId}, Permalink: permalink},
 	}
 }
 
@@ -0,0 +1,179 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/ExpediaGroup/flyte-client/flyte"
+	"github.com/ExpediaGroup/flyte-slack/cache"
+	"github.com/ExpediaGroup/flyte-slack/client"
+)
+
+var (
+	getChannelHistorySuccessEventDef = flyte.EventDef{Name: "GetChannelHistorySuccess"}
+	getChannelHistoryFailedEventDef  = flyte.EventDef{Name: "GetChannelHistoryFailed"}
+	getThreadRepliesSuccessEventDef  = flyte.EventDef{Name: "GetThreadRepliesSuccess"}
+	getThreadRepliesFailedEventDef   = flyte.EventDef{Name: "GetThreadRepliesFailed"}
+)
+
+type GetChannelHistoryInput struct {
+	ChannelInput
+	// Oldest and Latest are message timestamps bounding the history, both optional
+	Oldest string `json:"oldest"`
+	Latest string `json:"latest"`
+	// Limit defaults to and is capped by the configured history limit
+	Limit int `json:"limit"`
+}
+
+type GetChannelHistorySuccess struct {
+	GetChannelHistoryInput
+	Messages []client.MessageEvent `json:"messages"`
+	HasMore  bool                  `json:"hasMore"`
+}
+
+type GetChannelHistoryFail struct {
+	GetChannelHistoryInput
+	Reason string `json:"reason"`
+}
+
+type GetThreadRepliesInput struct {
+	ChannelInput
+	ThreadTimestamp string `json:"threadTimestamp"`
+	// Limit defaults to and is capped by the configured history limit
+	Limit int `json:"limit"`
+}
+
+type GetThreadRepliesSuccess struct {
+	GetThreadRepliesInput
+	Messages []client.MessageEvent `json:"messages"`
+	HasMore  bool                  `json:"hasMore"`
+}
+
+type GetThreadRepliesFail struct {
+	GetThreadRepliesInput
+	Reason string `json:"reason"`
+}
+
+// GetChannelHistory returns channel messages newest first, at most maxMessages of them
+func GetChannelHistory(slack client.Slack, cache cache.Cache, maxMessages int) flyte.Command {
+	return flyte.Command{
+		Name:         "GetChannelHistory",
+		OutputEvents: []flyte.EventDef{getChannelHistorySuccessEventDef, getChannelHistoryFailedEventDef},
+		Handler:      getChannelHistoryHandler(slack, cache, maxMessages),
+	}
+}
+
+func getChannelHistoryHandler(slack client.Slack, cache cache.Cache, maxMessages int) func(json.RawMessage) flyte.Event {
+	return func(rawInput json.RawMessage) flyte.Event {
+		input := GetChannelHistoryInput{}
+		if err := json.Unmarshal(rawInput, &input); err != nil {
+			return flyte.NewFatalEvent(fmt.Sprintf("input is not valid: %v", err))
+		}
+
+		if input.Limit < 0 {
+			return newGetChannelHistoryFail(input, "limit must not be negative")
+		}
+		if err := resolveChannelId(&input.ChannelInput, slack, cache); err != nil {
+			return newGetChannelHistoryFail(input, err.Error())
+		}
+
+		msgs, hasMore, err := slack.GetChannelHistory(input.ChannelId, input.Oldest, input.Latest, messageLimit(input.Limit, maxMessages))
+		if err != nil {
+			return newGetChannelHistoryFail(input, err.Error())
+		}
+
+		return flyte.Event{
+			EventDef: getChannelHistorySuccessEventDef,
+			Payload: GetChannelHistorySuccess{
+				GetChannelHistoryInput: input,
+				Messages:               msgs,
+				HasMore:                hasMore,
+			},
+		}
+	}
+}
+
+func newGetChannelHistoryFail(input GetChannelHistoryInput, reason string) flyte.Event {
+	return flyte.Event{
+		EventDef: getChannelHistoryFailedEventDef,
+		Payload: GetChannelHistoryFail{
+			GetChannelHistoryInput: input,
+			Reason:                 reason,
+		},
+	}
+}
+
+// GetThreadReplies returns thread messages oldest first including the thread root, at most maxMessages of them
+func GetThreadReplies(slack client.Slack, cache cache.Cache, maxMessages int) flyte.Command {
+	return flyte.Command{
+		Name:         "GetThreadReplies",
+		OutputEvents: []flyte.EventDef{getThreadRepliesSuccessEventDef, getThreadRepliesFailedEventDef},
+		Handler:      getThreadRepliesHandler(slack, cache, maxMessages),
+	}
+}
+
+func getThreadRepliesHandler(slack client.Slack, cache cache.Cache, maxMessages int) func(json.RawMessage) flyte.Event {
+	return func(rawInput json.RawMessage) flyte.Event {
+		input := GetThreadRepliesInput{}
+		if err := json.Unmarshal(rawInput, &input); err != nil {
+			return flyte.NewFatalEvent(fmt.Sprintf("input is not valid: %v", err))
+		}
+
+		if input.ThreadTimestamp == "" {
+			return newGetThreadRepliesFail(input, "missing thread timestamp field")
+		}
+		if input.Limit < 0 {
+			return newGetThreadRepliesFail(input, "limit must not be negative")
+		}
+		if err := resolveChannelId(&input.ChannelInput, slack, cache); err != nil {
+			return newGetThreadRepliesFail(input, err.Error())
+		}
+
+		msgs, hasMore, err := slack.GetThreadReplies(input.ChannelId, input.ThreadTimestamp, messageLimit(input.Limit, maxMessages))
+		if err != nil {
+			return newGetThreadRepliesFail(input, err.Error())
+		}
+
+		return flyte.Event{
+			EventDef: getThreadRepliesSuccessEventDef,
+			Payload: GetThreadRepliesSuccess{
+				GetThreadRepliesInput: input,
+				Messages:              msgs,
+				HasMore:               hasMore,
+			},
+		}
+	}
+}
+
+func newGetThreadRepliesFail(input GetThreadRepliesInput, reason string) flyte.Event {
+	return flyte.Event{
+		EventDef: getThreadRepliesFailedEventDef,
+		Payload: GetThreadRepliesFail{
+			GetThreadRepliesInput: input,
+			Reason:                reason,
+		},
+	}
+}
+
+// messageLimit caps requested number of messages, 0 means as many as allowed
+func messageLimit(requested, max int) int {
+	if requested == 0 || requested > max {
+		return max
+	}
+	return requested
+}
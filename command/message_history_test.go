@@ -0,0 +1,74 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"errors"
+	"github.com/ExpediaGroup/flyte-slack/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestGetChannelHistoryCapsLimit(t *testing.T) {
+	slack := NewMockSlack()
+	slack.GetChannelHistoryFunc = func(channelId, oldest, latest string, limit int) ([]client.MessageEvent, bool, error) {
+		assert.Equal(t, "C123", channelId)
+		assert.Equal(t, "1600000000.000100", oldest)
+		assert.Equal(t, 100, limit)
+		return []client.MessageEvent{{ChannelId: channelId, Message: "rolled back"}}, true, nil
+	}
+
+	event := GetChannelHistory(slack, newTestCache(), 100).Handler([]byte(`{"channelId": "C123", "oldest": "1600000000.000100", "limit": 5000}`))
+
+	require.Equal(t, getChannelHistorySuccessEventDef, event.EventDef)
+	output := event.Payload.(GetChannelHistorySuccess)
+	assert.True(t, output.HasMore)
+	assert.Equal(t, "rolled back", output.Messages[0].Message)
+}
+
+func TestGetChannelHistoryFails(t *testing.T) {
+	slack := NewMockSlack()
+	slack.GetChannelHistoryFunc = func(channelId, oldest, latest string, limit int) ([]client.MessageEvent, bool, error) {
+		return nil, false, errors.New("cannot get history of channel=C123: not_in_channel")
+	}
+
+	event := GetChannelHistory(slack, newTestCache(), 100).Handler([]byte(`{"channelId": "C123"}`))
+
+	require.Equal(t, getChannelHistoryFailedEventDef, event.EventDef)
+	assert.Equal(t, "cannot get history of channel=C123: not_in_channel", event.Payload.(GetChannelHistoryFail).Reason)
+}
+
+func TestGetThreadReplies(t *testing.T) {
+	slack := NewMockSlack()
+	slack.GetThreadRepliesFunc = func(channelId, threadTimestamp string, limit int) ([]client.MessageEvent, bool, error) {
+		assert.Equal(t, 20, limit)
+		return []client.MessageEvent{{Timestamp: threadTimestamp}, {Timestamp: "1.1"}}, false, nil
+	}
+
+	event := GetThreadReplies(slack, newTestCache(), 100).Handler([]byte(`{"channelId": "C123", "threadTimestamp": "1.0", "limit": 20}`))
+
+	require.Equal(t, getThreadRepliesSuccessEventDef, event.EventDef)
+	assert.Len(t, event.Payload.(GetThreadRepliesSuccess).Messages, 2)
+}
+
+func TestGetThreadRepliesFailsWithoutThreadTimestamp(t *testing.T) {
+	event := GetThreadReplies(NewMockSlack(), newTestCache(), 100).Handler([]byte(`{"channelId": "C123"}`))
+
+	require.Equal(t, getThreadRepliesFailedEventDef, event.EventDef)
+	assert.Equal(t, "missing thread timestamp field", event.Payload.(GetThreadRepliesFail).Reason)
+}
@@ -17,8 +17,10 @@ limitations under the License.
 package command
 
 import (
+	"errors"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"strings"
 	"testing"
 )
 
@@ -30,7 +32,7 @@ func BeforeMessage() {
 
 func TestSendMessageCommandIsPopulated(t *testing.T) {
 
-	command := SendMessage(MessageMockSlack)
+	command := SendMessage(MessageMockSlack, nil)
 
 	assert.Equal(t, "SendMessage", command.Name)
 	require.Equal(t, 2, len(command.OutputEvents))
@@ -41,7 +43,7 @@ func TestSendMessageCommandIsPopulated(t *testing.T) {
 func TestSendsMessageSendsMessageToSlack(t *testing.T) {
 	BeforeMessage()
 
-	handler := SendMessage(MessageMockSlack).Handler
+	handler := SendMessage(MessageMockSlack, nil).Handler
 	handler([]byte(`{"message": "hello from flyte", "channelId": "abc-channel"}`))
 
 	calls := MessageMockSlack.SendMessageCalls
@@ -53,7 +55,7 @@ func TestSendsMessageSendsMessageToSlack(t *testing.T) {
 func TestSendMessageReturnsMessageSentEvent(t *testing.T) {
 	BeforeMessage()
 
-	handler := SendMessage(MessageMockSlack).Handler
+	handler := SendMessage(MessageMockSlack, nil).Handler
 	event := handler([]byte(`{"message": "yo", "channelId": "xyz"}`))
 
 	output := event.Payload.(SendMessageOutput)
@@ -65,7 +67,7 @@ func TestSendMessageReturnsMessageSentEvent(t *testing.T) {
 func TestSendMessageHandleInvalidJsonInput(t *testing.T) {
 	BeforeMessage()
 
-	handler := SendMessage(MessageMockSlack).Handler
+	handler := SendMessage(MessageMockSlack, nil).Handler
 	event := handler([]byte(`--- invalid json input ---`))
 
 	output, ok := event.Payload.(string)
@@ -76,7 +78,7 @@ func TestSendMessageHandleInvalidJsonInput(t *testing.T) {
 func TestSendMessageHandleInputWithMissingMessage(t *testing.T) {
 	BeforeMessage()
 
-	handler := SendMessage(MessageMockSlack).Handler
+	handler := SendMessage(MessageMockSlack, nil).Handler
 	event := handler([]byte(`{"channelId": "UXB456Y"}`))
 
 	output := event.Payload.(SendMessageErrorOutput)
@@ -87,7 +89,7 @@ func TestSendMessageHandleInputWithMissingMessage(t *testing.T) {
 func TestSendMessageHandleInputWithMissingChannelId(t *testing.T) {
 	BeforeMessage()
 
-	handler := SendMessage(MessageMockSlack).Handler
+	handler := SendMessage(MessageMockSlack, nil).Handler
 	event := handler([]byte(`{"message": "oh, the channel id is missing"}`))
 
 	output := event.Payload.(SendMessageErrorOutput)
@@ -98,10 +100,74 @@ func TestSendMessageHandleInputWithMissingChannelId(t *testing.T) {
 func TestSendMessageHandleInputWithMissingMessageAndChannelId(t *testing.T) {
 	BeforeMessage()
 
-	handler := SendMessage(MessageMockSlack).Handler
+	handler := SendMessage(MessageMockSlack, nil).Handler
 	event := handler([]byte(`{}`))
 
 	output := event.Payload.(SendMessageErrorOutput)
 	assert.Equal(t, "SendMessageFailed", event.EventDef.Name)
 	assert.Equal(t, "missing message field, missing channel id field", output.Error)
 }
+
+func TestSendMessageConvertsMarkdown(t *testing.T) {
+	BeforeMessage()
+
+	handler := SendMessage(MessageMockSlack, nil).Handler
+	event := handler([]byte(`{"message": "**deploy** of [app](https://ci/1)", "channelId": "abc-channel", "format": "markdown"}`))
+
+	assert.Equal(t, "MessageSent", event.EventDef.Name)
+	assert.Equal(t, "*deploy* of <https://ci/1|app>", MessageMockSlack.SendMessageCalls["abc-channel"][0])
+}
+
+func TestSendMessageHandleInputWithUnknownFormat(t *testing.T) {
+	BeforeMessage()
+
+	handler := SendMessage(MessageMockSlack, nil).Handler
+	event := handler([]byte(`{"message": "hi", "channelId": "abc-channel", "format": "html"}`))
+
+	output := event.Payload.(SendMessageErrorOutput)
+	assert.Equal(t, "SendMessageFailed", event.EventDef.Name)
+	assert.Equal(t, `unknown format="html", expected "mrkdwn" or "markdown"`, output.Error)
+	assert.Empty(t, MessageMockSlack.SendMessageCalls)
+}
+
+func TestSendMessageReturnsPermalink(t *testing.T) {
+	BeforeMessage()
+	MessageMockSlack.SendMessageFunc = func(message, channelId, threadTimestamp string) (string, error) {
+		return "1234.5678", nil
+	}
+	MessageMockSlack.GetPermalinkFunc = func(channelId, timestamp string) (string, error) {
+		return "https://example.slack.com/archives/" + channelId + "/p" + strings.Replace(timestamp, ".", "", 1), nil
+	}
+
+	event := SendMessage(MessageMockSlack, nil).Handler([]byte(`{"message": "yo", "channelId": "xyz"}`))
+
+	assert.Equal(t, "MessageSent", event.EventDef.Name)
+	assert.Equal(t, "https://example.slack.com/archives/xyz/p12345678", event.Payload.(SendMessageOutput).Permalink)
+}
+
+func TestSendMessageReturnsMessageSentEventWhenPermalinkFails(t *testing.T) {
+	BeforeMessage()
+	MessageMockSlack.SendMessageFunc = func(message, channelId, threadTimestamp string) (string, error) {
+		return "1234.5678", nil
+	}
+	MessageMockSlack.GetPermalinkFunc = func(channelId, timestamp string) (string, error) {
+		return "", errors.New("ratelimited")
+	}
+
+	event := SendMessage(MessageMockSlack, nil).Handler([]byte(`{"message": "yo", "channelId": "xyz"}`))
+
+	assert.Equal(t, "MessageSent", event.EventDef.Name)
+	assert.Equal(t, "", event.Payload.(SendMessageOutput).Permalink)
+}
+
+func TestSendMessageReturnsErrorEventWhenSlackFails(t *testing.T) {
+	BeforeMessage()
+	MessageMockSlack.SendMessageFunc = func(message, channelId, threadTimestamp string) (string, error) {
+		return "", errors.New("cannot send message to channel=xyz: channel_not_found")
+	}
+
+	event := SendMessage(MessageMockSlack, nil).Handler([]byte(`{"message": "yo", "channelId": "xyz"}`))
+
+	assert.Equal(t, "SendMessageFailed", event.EventDef.Name)
+	assert.Equal(t, "cannot send message to channel=xyz: channel_not_found", event.Payload.(SendMessageErrorOutput).Error)
+}
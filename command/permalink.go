@@ -0,0 +1,97 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/ExpediaGroup/flyte-client/flyte"
+	"github.com/ExpediaGroup/flyte-slack/cache"
+	"github.com/ExpediaGroup/flyte-slack/client"
+)
+
+var (
+	getPermalinkSuccessEventDef = flyte.EventDef{Name: "GetPermalinkSuccess"}
+	getPermalinkFailedEventDef  = flyte.EventDef{Name: "GetPermalinkFailed"}
+)
+
+// PermalinkGetter is used to add permalinks to sent message events
+type PermalinkGetter interface {
+	GetPermalink(channelId, timestamp string) (string, error)
+}
+
+type GetPermalinkInput struct {
+	ChannelInput
+	Timestamp string `json:"timestamp"`
+}
+
+type GetPermalinkSuccess struct {
+	GetPermalinkInput
+	Permalink string `json:"permalink"`
+}
+
+type GetPermalinkFail struct {
+	GetPermalinkInput
+	Reason string `json:"reason"`
+}
+
+func GetPermalink(slack client.Slack, cache cache.Cache) flyte.Command {
+	return flyte.Command{
+		Name:         "GetPermalink",
+		OutputEvents: []flyte.EventDef{getPermalinkSuccessEventDef, getPermalinkFailedEventDef},
+		Handler:      getPermalinkHandler(slack, cache),
+	}
+}
+
+func getPermalinkHandler(slack client.Slack, cache cache.Cache) func(json.RawMessage) flyte.Event {
+	return func(rawInput json.RawMessage) flyte.Event {
+		input := GetPermalinkInput{}
+		if err := json.Unmarshal(rawInput, &input); err != nil {
+			return flyte.NewFatalEvent(fmt.Sprintf("input is not valid: %v", err))
+		}
+
+		if input.Timestamp == "" {
+			return newGetPermalinkFail(input, "missing timestamp field")
+		}
+		if err := resolveChannelId(&input.ChannelInput, slack, cache); err != nil {
+			return newGetPermalinkFail(input, err.Error())
+		}
+
+		p, err := slack.GetPermalink(input.ChannelId, input.Timestamp)
+		if err != nil {
+			return newGetPermalinkFail(input, err.Error())
+		}
+
+		return flyte.Event{
+			EventDef: getPermalinkSuccessEventDef,
+			Payload: GetPermalinkSuccess{
+				GetPermalinkInput: input,
+				Permalink:         p,
+			},
+		}
+	}
+}
+
+func newGetPermalinkFail(input GetPermalinkInput, reason string) flyte.Event {
+	return flyte.Event{
+		EventDef: getPermalinkFailedEventDef,
+		Payload: GetPermalinkFail{
+			GetPermalinkInput: input,
+			Reason:            reason,
+		},
+	}
+}
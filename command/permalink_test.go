@@ -0,0 +1,57 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"errors"
+	"github.com/ExpediaGroup/flyte-slack/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestGetPermalink(t *testing.T) {
+	slack := NewMockSlack()
+	slack.GetConversationsFunc = func() ([]types.Conversation, error) {
+		return []types.Conversation{{ID: "C123", Name: "alerts"}}, nil
+	}
+	slack.GetPermalinkFunc = func(channelId, timestamp string) (string, error) {
+		assert.Equal(t, "C123", channelId)
+		assert.Equal(t, "1234.5678", timestamp)
+		return "https://example.slack.com/archives/C123/p12345678", nil
+	}
+
+	event := GetPermalink(slack, newTestCache()).Handler([]byte(`{"channelName": "alerts", "timestamp": "1234.5678"}`))
+
+	require.Equal(t, getPermalinkSuccessEventDef, event.EventDef)
+	assert.Equal(t, "https://example.slack.com/archives/C123/p12345678", event.Payload.(GetPermalinkSuccess).Permalink)
+}
+
+func TestGetPermalinkFails(t *testing.T) {
+	slack := NewMockSlack()
+	slack.GetPermalinkFunc = func(channelId, timestamp string) (string, error) {
+		return "", errors.New("cannot get permalink of message=1234.5678 in channel=C123: message_not_found")
+	}
+
+	missing := GetPermalink(slack, newTestCache()).Handler([]byte(`{"channelId": "C123"}`))
+	failed := GetPermalink(slack, newTestCache()).Handler([]byte(`{"channelId": "C123", "timestamp": "1234.5678"}`))
+
+	require.Equal(t, getPermalinkFailedEventDef, missing.EventDef)
+	assert.Equal(t, "missing timestamp field", missing.Payload.(GetPermalinkFail).Reason)
+	require.Equal(t, getPermalinkFailedEventDef, failed.EventDef)
+	assert.Equal(t, "cannot get permalink of message=1234.5678 in channel=C123: message_not_found", failed.Payload.(GetPermalinkFail).Reason)
+}
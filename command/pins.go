@@ -0,0 +1,164 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/ExpediaGroup/flyte-client/flyte"
+	"github.com/ExpediaGroup/flyte-slack/client"
+	"github.com/ExpediaGroup/flyte-slack/types"
+	"strings"
+)
+
+var (
+	pinMessageSuccessEventDef   = flyte.EventDef{Name: "PinMessageSuccess"}
+	pinMessageFailedEventDef    = flyte.EventDef{Name: "PinMessageFailed"}
+	unpinMessageSuccessEventDef = flyte.EventDef{Name: "UnpinMessageSuccess"}
+	unpinMessageFailedEventDef  = flyte.EventDef{Name: "UnpinMessageFailed"}
+	listPinsSuccessEventDef     = flyte.EventDef{Name: "ListPinsSuccess"}
+	listPinsFailedEventDef      = flyte.EventDef{Name: "ListPinsFailed"}
+)
+
+// PinMessageInput is input of both PinMessage and UnpinMessage
+type PinMessageInput struct {
+	ChannelId string `json:"channelId"`
+	Timestamp string `json:"timestamp"`
+}
+
+type PinMessageSuccess struct {
+	PinMessageInput
+}
+
+type PinMessageFail struct {
+	PinMessageInput
+	Reason string `json:"reason"`
+}
+
+type ListPinsInput struct {
+	ChannelId string `json:"channelId"`
+}
+
+type ListPinsSuccess struct {
+	ListPinsInput
+	Pins []types.Pin `json:"pins"`
+}
+
+type ListPinsFail struct {
+	ListPinsInput
+	Reason string `json:"reason"`
+}
+
+func PinMessage(slack client.Slack) flyte.Command {
+	return flyte.Command{
+		Name:         "PinMessage",
+		OutputEvents: []flyte.EventDef{pinMessageSuccessEventDef, pinMessageFailedEventDef},
+		Handler:      pinMessageHandler(slack.PinMessage, pinMessageSuccessEventDef, pinMessageFailedEventDef),
+	}
+}
+
+func UnpinMessage(slack client.Slack) flyte.Command {
+	return flyte.Command{
+		Name:         "UnpinMessage",
+		OutputEvents: []flyte.EventDef{unpinMessageSuccessEventDef, unpinMessageFailedEventDef},
+		Handler:      pinMessageHandler(slack.UnpinMessage, unpinMessageSuccessEventDef, unpinMessageFailedEventDef),
+	}
+}
+
+// pinMessageHandler is shared by PinMessage and UnpinMessage, which differ only in slack call and events
+func pinMessageHandler(pin func(channelId, timestamp string) error, success, fail flyte.EventDef) func(json.RawMessage) flyte.Event {
+	return func(rawInput json.RawMessage) flyte.Event {
+		input := PinMessageInput{}
+		if err := json.Unmarshal(rawInput, &input); err != nil {
+			return flyte.NewFatalEvent(fmt.Sprintf("input is not valid: %v", err))
+		}
+
+		errorMessages := []string{}
+		if input.ChannelId == "" {
+			errorMessages = append(errorMessages, "missing channel id field")
+		}
+		if input.Timestamp == "" {
+			errorMessages = append(errorMessages, "missing timestamp field")
+		}
+		if len(errorMessages) != 0 {
+			return newPinMessageFail(fail, input, strings.Join(errorMessages, ", "))
+		}
+
+		if err := pin(input.ChannelId, input.Timestamp); err != nil {
+			return newPinMessageFail(fail, input, err.Error())
+		}
+
+		return flyte.Event{
+			EventDef: success,
+			Payload:  PinMessageSuccess{PinMessageInput: input},
+		}
+	}
+}
+
+func newPinMessageFail(fail flyte.EventDef, input PinMessageInput, reason string) flyte.Event {
+	return flyte.Event{
+		EventDef: fail,
+		Payload: PinMessageFail{
+			PinMessageInput: input,
+			Reason:          reason,
+		},
+	}
+}
+
+func ListPins(slack client.Slack) flyte.Command {
+	return flyte.Command{
+		Name:         "ListPins",
+		OutputEvents: []flyte.EventDef{listPinsSuccessEventDef, listPinsFailedEventDef},
+		Handler:      listPinsHandler(slack),
+	}
+}
+
+func listPinsHandler(slack client.Slack) func(json.RawMessage) flyte.Event {
+	return func(rawInput json.RawMessage) flyte.Event {
+		input := ListPinsInput{}
+		if err := json.Unmarshal(rawInput, &input); err != nil {
+			return flyte.NewFatalEvent(fmt.Sprintf("input is not valid: %v", err))
+		}
+
+		if input.ChannelId == "" {
+			return newListPinsFail(input, "missing channel id field")
+		}
+
+		pins, err := slack.ListPins(input.ChannelId)
+		if err != nil {
+			return newListPinsFail(input, err.Error())
+		}
+
+		return flyte.Event{
+			EventDef: listPinsSuccessEventDef,
+			Payload: ListPinsSuccess{
+				ListPinsInput: input,
+				Pins:          pins,
+			},
+		}
+	}
+}
+
+func newListPinsFail(input ListPinsInput, reason string) flyte.Event {
+	return flyte.Event{
+		EventDef: listPinsFailedEventDef,
+		Payload: ListPinsFail{
+			ListPinsInput: input,
+			Reason:        reason,
+		},
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"errors"
+	"github.com/ExpediaGroup/flyte-slack/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestPinCommandsArePopulated(t *testing.T) {
+	slack := NewMockSlack()
+
+	pin := PinMessage(slack)
+	assert.Equal(t, "PinMessage", pin.Name)
+	require.Equal(t, 2, len(pin.OutputEvents))
+	assert.Equal(t, "PinMessageSuccess", pin.OutputEvents[0].Name)
+	assert.Equal(t, "PinMessageFailed", pin.OutputEvents[1].Name)
+
+	unpin := UnpinMessage(slack)
+	assert.Equal(t, "UnpinMessage", unpin.Name)
+	require.Equal(t, 2, len(unpin.OutputEvents))
+	assert.Equal(t, "UnpinMessageSuccess", unpin.OutputEvents[0].Name)
+	assert.Equal(t, "UnpinMessageFailed", unpin.OutputEvents[1].Name)
+
+	list := ListPins(slack)
+	assert.Equal(t, "ListPins", list.Name)
+	require.Equal(t, 2, len(list.OutputEvents))
+	assert.Equal(t, "ListPinsSuccess", list.OutputEvents[0].Name)
+	assert.Equal(t, "ListPinsFailed", list.OutputEvents[1].Name)
+}
+
+func TestPinMessage(t *testing.T) {
+	slack := NewMockSlack()
+	var pinned []string
+	slack.PinMessageFunc = func(channelId, timestamp string) error {
+		pinned = append(pinned, channelId+"/"+timestamp)
+		return nil
+	}
+
+	event := PinMessage(slack).Handler([]byte(`{"channelId": "C123", "timestamp": "1.0"}`))
+
+	require.Equal(t, pinMessageSuccessEventDef, event.EventDef)
+	assert.Equal(t, PinMessageSuccess{PinMessageInput{ChannelId: "C123", Timestamp: "1.0"}}, event.Payload)
+	assert.Equal(t, []string{"C123/1.0"}, pinned)
+}
+
+func TestUnpinMessageFails(t *testing.T) {
+	slack := NewMockSlack()
+	slack.UnpinMessageFunc = func(channelId, timestamp string) error {
+		return errors.New("cannot unpin message=1.0 in channel=C123: not_in_channel")
+	}
+
+	tests := []struct {
+		name   string
+		input  string
+		reason string
+	}{
+		{name: "missing fields", input: `{}`, reason: "missing channel id field, missing timestamp field"},
+		{name: "slack error", input: `{"channelId": "C123", "timestamp": "1.0"}`, reason: "cannot unpin message=1.0 in channel=C123: not_in_channel"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			event := UnpinMessage(slack).Handler([]byte(test.input))
+
+			require.Equal(t, unpinMessageFailedEventDef, event.EventDef)
+			assert.Equal(t, test.reason, event.Payload.(PinMessageFail).Reason)
+		})
+	}
+}
+
+func TestListPins(t *testing.T) {
+	slack := NewMockSlack()
+	slack.ListPinsFunc = func(channelId string) ([]types.Pin, error) {
+		return []types.Pin{{Type: "message", Timestamp: "1.0", User: "U1", Text: "status: mitigated"}}, nil
+	}
+
+	event := ListPins(slack).Handler([]byte(`{"channelId": "C123"}`))
+
+	require.Equal(t, listPinsSuccessEventDef, event.EventDef)
+	output := event.Payload.(ListPinsSuccess)
+	assert.Equal(t, "C123", output.ChannelId)
+	assert.Equal(t, "status: mitigated", output.Pins[0].Text)
+}
@@ -36,17 +36,19 @@ type SendRichMessageErrorOutput struct {
 
 type RichMessageSender interface {
 	SendRichMessage(rm client.RichMessage) (respChannel string, respTimestamp string, err error)
+	PermalinkGetter
 }
 
-func SendRichMessage(sender RichMessageSender) flyte.Command {
+// SendRichMessage sends rich message resolving mention placeholders in it, mentions can be nil
+func SendRichMessage(sender RichMessageSender, mentions MentionResolver) flyte.Command {
 	return flyte.Command{
 		Name:         "SendRichMessage",
 		OutputEvents: []flyte.EventDef{richMessageSentEventDef, sendRichMessageFailedEventDef},
-		Handler:      sendRichMessageHandler(sender),
+		Handler:      sendRichMessageHandler(sender, mentions),
 	}
 }
 
-func sendRichMessageHandler(sender RichMessageSender) flyte.CommandHandler {
+func sendRichMessageHandler(sender RichMessageSender, mentions MentionResolver) flyte.CommandHandler {
 	return func(rawInput json.RawMessage) flyte.Event {
 		var input client.RichMessage
 		if err := json.Unmarshal(rawInput, &input); err != nil {
@@ -55,16 +57,15 @@ func sendRichMessageHandler(sender RichMessageSender) flyte.CommandHandler {
 			return flyte.NewFatalEvent(errorMessage)
 		}
 
-		respChannel, respTimestamp, err := sender.SendRichMessage(input)
+		rm, err := resolveRichMessageMentions(mentions, input)
+		if err != nil {
+			return newSendRichMessageFailedEvent(input, err)
+		}
+
+		respChannel, respTimestamp, err := sender.SendRichMessage(rm)
 		if err != nil {
 			log.Err(err).Msg("error sending rich message")
-			return flyte.Event{
-				EventDef: sendRichMessageFailedEventDef,
-				Payload: SendRichMessageErrorOutput{
-					InputMessage: input,
-					Error:        err.Error(),
-				},
-			}
+			return newSendRichMessageFailedEvent(input, err)
 		}
 
 		return flyte.Event{
@@ -72,7 +73,18 @@ func sendRichMessageHandler(sender RichMessageSender) flyte.CommandHandler {
 			Payload: map[string]string{
 				"channelId":       respChannel,
 				"threadTimestamp": respTimestamp,
+				"permalink":       sentMessagePermalink(sender, respChannel, respTimestamp),
 			},
 		}
 	}
 }
+
+func newSendRichMessageFailedEvent(input client.RichMessage, err error) flyte.Event {
+	return flyte.Event{
+		EventDef: sendRichMessageFailedEventDef,
+		Payload: SendRichMessageErrorOutput{
+			InputMessage: input,
+			Error:        err.Error(),
+		},
+	}
+}
@@ -27,7 +27,7 @@ import (
 )
 
 func TestPostMessageCommandIsPopulated(t *testing.T) {
-	command := SendRichMessage(nil)
+	command := SendRichMessage(nil, nil)
 
 	assert.Equal(t, "SendRichMessage", command.Name)
 	require.Equal(t, 2, len(command.OutputEvents))
@@ -36,7 +36,7 @@ func TestPostMessageCommandIsPopulated(t *testing.T) {
 }
 
 func TestPostMessageShouldReturnFatalErrorEventWhenCalledWithInvalidJSON(t *testing.T) {
-	cmd := SendRichMessage(nil)
+	cmd := SendRichMessage(nil, nil)
 
 	event := cmd.Handler([]byte(`.`))
 
@@ -52,7 +52,7 @@ func TestSendRichMessageHandlerShouldReturnErrorEventWhenRichMessageSenderReturn
 		},
 	}
 
-	command := SendRichMessage(mp)
+	command := SendRichMessage(mp, nil)
 
 	event := command.Handler(testRichMessage())
 
@@ -71,7 +71,7 @@ func TestPostMessageCallsMessagePoster(t *testing.T) {
 		},
 	}
 
-	command := SendRichMessage(mp)
+	command := SendRichMessage(mp, nil)
 
 	command.Handler(testRichMessage())
 
@@ -87,7 +87,7 @@ func TestPostMessageReturnsEventWithInputMessageAsPayload(t *testing.T) {
 			return "AB45787HU", "1234.5678", nil
 		},
 	}
-	command := SendRichMessage(mp)
+	command := SendRichMessage(mp, nil)
 
 	event := command.Handler(testRichMessage())
 	im := event.Payload.(map[string]string)
@@ -98,13 +98,28 @@ func TestPostMessageReturnsEventWithInputMessageAsPayload(t *testing.T) {
 	assert.Equal(t, "AB45787HU", im["channelId"])
 }
 
+func TestPostMessageReturnsPermalink(t *testing.T) {
+	mp := mockRichMessageSender{
+		sendRichMessage: func(rm client.RichMessage) (string, string, error) {
+			return "AB45787HU", "1234.5678", nil
+		},
+		getPermalink: func(channelId, timestamp string) (string, error) {
+			return "https://example.slack.com/archives/AB45787HU/p12345678", nil
+		},
+	}
+
+	event := SendRichMessage(mp, nil).Handler(testRichMessage())
+
+	assert.Equal(t, "https://example.slack.com/archives/AB45787HU/p12345678", event.Payload.(map[string]string)["permalink"])
+}
+
 func TestWiring(t *testing.T) {
 	slack := NewMockSlack()
 	slack.SendRichMessageFunc = func(rm client.RichMessage) (string, string, error) {
 		return "", "", nil
 	}
 
-	command := SendRichMessage(slack)
+	command := SendRichMessage(slack, nil)
 
 	event := command.Handler(testRichMessage())
 
@@ -150,6 +165,14 @@ func testRichMessageStruct() client.RichMessage {
 
 type mockRichMessageSender struct {
 	sendRichMessage func(rm client.RichMessage) (string, string, error)
+	getPermalink    func(channelId, timestamp string) (string, error)
+}
+
+func (m mockRichMessageSender) GetPermalink(channelId, timestamp string) (string, error) {
+	if m.getPermalink != nil {
+		return m.getPermalink(channelId, timestamp)
+	}
+	return "", nil
 }
 
 func (m mockRichMessageSender) SendRichMessage(rm client.RichMessage) (string, string, error) {
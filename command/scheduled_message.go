@@ -0,0 +1,242 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/ExpediaGroup/flyte-client/flyte"
+	"github.com/ExpediaGroup/flyte-slack/client"
+	"github.com/ExpediaGroup/flyte-slack/types"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	messageScheduledEventDef             = flyte.EventDef{Name: "MessageScheduled"}
+	scheduleMessageFailedEventDef        = flyte.EventDef{Name: "ScheduleMessageFailed"}
+	scheduledMessagesListedEventDef      = flyte.EventDef{Name: "ScheduledMessagesListed"}
+	listScheduledMessagesFailedEventDef  = flyte.EventDef{Name: "ListScheduledMessagesFailed"}
+	scheduledMessageDeletedEventDef      = flyte.EventDef{Name: "ScheduledMessageDeleted"}
+	deleteScheduledMessageFailedEventDef = flyte.EventDef{Name: "DeleteScheduledMessageFailed"}
+)
+
+type ScheduleMessageInput struct {
+	Message         string `json:"message"`
+	ChannelId       string `json:"channelId"`
+	ThreadTimestamp string `json:"threadTimestamp"`
+	// PostAt is either unix time in seconds or RFC3339 timestamp with timezone
+	PostAt string `json:"postAt"`
+}
+
+type ScheduleMessageSuccess struct {
+	ScheduleMessageInput
+	ScheduledMessageId string `json:"scheduledMessageId"`
+}
+
+type ScheduleMessageFail struct {
+	ScheduleMessageInput
+	Reason string `json:"reason"`
+}
+
+type ListScheduledMessagesInput struct {
+	ChannelId string `json:"channelId"`
+}
+
+type ListScheduledMessagesSuccess struct {
+	ListScheduledMessagesInput
+	ScheduledMessages []types.ScheduledMessage `json:"scheduledMessages"`
+}
+
+type ListScheduledMessagesFail struct {
+	ListScheduledMessagesInput
+	Reason string `json:"reason"`
+}
+
+type DeleteScheduledMessageInput struct {
+	ChannelId          string `json:"channelId"`
+	ScheduledMessageId string `json:"scheduledMessageId"`
+}
+
+type DeleteScheduledMessageSuccess struct {
+	DeleteScheduledMessageInput
+}
+
+type DeleteScheduledMessageFail struct {
+	DeleteScheduledMessageInput
+	Reason string `json:"reason"`
+}
+
+func ScheduleMessage(slack client.Slack) flyte.Command {
+	return flyte.Command{
+		Name:         "ScheduleMessage",
+		OutputEvents: []flyte.EventDef{messageScheduledEventDef, scheduleMessageFailedEventDef},
+		Handler:      scheduleMessageHandler(slack),
+	}
+}
+
+func scheduleMessageHandler(slack client.Slack) func(json.RawMessage) flyte.Event {
+	return func(rawInput json.RawMessage) flyte.Event {
+		input := ScheduleMessageInput{}
+		if err := json.Unmarshal(rawInput, &input); err != nil {
+			return flyte.NewFatalEvent(fmt.Sprintf("input is not valid: %v", err))
+		}
+
+		errorMessages := []string{}
+		if input.Message == "" {
+			errorMessages = append(errorMessages, "missing message field")
+		}
+		if input.ChannelId == "" {
+			errorMessages = append(errorMessages, "missing channel id field")
+		}
+		postAt, err := parsePostAt(input.PostAt)
+		if err != nil {
+			errorMessages = append(errorMessages, err.Error())
+		}
+		if len(errorMessages) != 0 {
+			return newScheduleMessageFail(input, strings.Join(errorMessages, ", "))
+		}
+
+		id, err := slack.ScheduleMessage(input.Message, input.ChannelId, input.ThreadTimestamp, postAt)
+		if err != nil {
+			return newScheduleMessageFail(input, err.Error())
+		}
+
+		return flyte.Event{
+			EventDef: messageScheduledEventDef,
+			Payload: ScheduleMessageSuccess{
+				ScheduleMessageInput: input,
+				ScheduledMessageId:   id,
+			},
+		}
+	}
+}
+
+func parsePostAt(postAt string) (time.Time, error) {
+	if postAt == "" {
+		return time.Time{}, fmt.Errorf("missing post at field")
+	}
+	return parseTime("post at", postAt)
+}
+
+// parseTime accepts unix time in seconds or RFC3339 timestamp, e.g. "2026-10-20T09:00:00+01:00",
+// field is only used in the error message
+func parseTime(field, value string) (time.Time, error) {
+	if unix, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(unix, 0), nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s=%q is neither unix time nor RFC3339 timestamp", field, value)
+	}
+	return t, nil
+}
+
+func newScheduleMessageFail(input ScheduleMessageInput, reason string) flyte.Event {
+	return flyte.Event{
+		EventDef: scheduleMessageFailedEventDef,
+		Payload: ScheduleMessageFail{
+			ScheduleMessageInput: input,
+			Reason:               reason,
+		},
+	}
+}
+
+func ListScheduledMessages(slack client.Slack) flyte.Command {
+	return flyte.Command{
+		Name:         "ListScheduledMessages",
+		OutputEvents: []flyte.EventDef{scheduledMessagesListedEventDef, listScheduledMessagesFailedEventDef},
+		Handler:      listScheduledMessagesHandler(slack),
+	}
+}
+
+func listScheduledMessagesHandler(slack client.Slack) func(json.RawMessage) flyte.Event {
+	return func(rawInput json.RawMessage) flyte.Event {
+		input := ListScheduledMessagesInput{}
+		if err := json.Unmarshal(rawInput, &input); err != nil {
+			return flyte.NewFatalEvent(fmt.Sprintf("input is not valid: %v", err))
+		}
+
+		msgs, err := slack.ListScheduledMessages(input.ChannelId)
+		if err != nil {
+			return flyte.Event{
+				EventDef: listScheduledMessagesFailedEventDef,
+				Payload: ListScheduledMessagesFail{
+					ListScheduledMessagesInput: input,
+					Reason:                     err.Error(),
+				},
+			}
+		}
+
+		return flyte.Event{
+			EventDef: scheduledMessagesListedEventDef,
+			Payload: ListScheduledMessagesSuccess{
+				ListScheduledMessagesInput: input,
+				ScheduledMessages:          msgs,
+			},
+		}
+	}
+}
+
+func DeleteScheduledMessage(slack client.Slack) flyte.Command {
+	return flyte.Command{
+		Name:         "DeleteScheduledMessage",
+		OutputEvents: []flyte.EventDef{scheduledMessageDeletedEventDef, deleteScheduledMessageFailedEventDef},
+		Handler:      deleteScheduledMessageHandler(slack),
+	}
+}
+
+func deleteScheduledMessageHandler(slack client.Slack) func(json.RawMessage) flyte.Event {
+	return func(rawInput json.RawMessage) flyte.Event {
+		input := DeleteScheduledMessageInput{}
+		if err := json.Unmarshal(rawInput, &input); err != nil {
+			return flyte.NewFatalEvent(fmt.Sprintf("input is not valid: %v", err))
+		}
+
+		errorMessages := []string{}
+		if input.ChannelId == "" {
+			errorMessages = append(errorMessages, "missing channel id field")
+		}
+		if input.ScheduledMessageId == "" {
+			errorMessages = append(errorMessages, "missing scheduled message id field")
+		}
+		if len(errorMessages) != 0 {
+			return newDeleteScheduledMessageFail(input, strings.Join(errorMessages, ", "))
+		}
+
+		if err := slack.DeleteScheduledMessage(input.ChannelId, input.ScheduledMessageId); err != nil {
+			return newDeleteScheduledMessageFail(input, err.Error())
+		}
+
+		return flyte.Event{
+			EventDef: scheduledMessageDeletedEventDef,
+			Payload:  DeleteScheduledMessageSuccess{DeleteScheduledMessageInput: input},
+		}
+	}
+}
+
+func newDeleteScheduledMessageFail(input DeleteScheduledMessageInput, reason string) flyte.Event {
+	return flyte.Event{
+		EventDef: deleteScheduledMessageFailedEventDef,
+		Payload: DeleteScheduledMessageFail{
+			DeleteScheduledMessageInput: input,
+			Reason:                      reason,
+		},
+	}
+}
@@ -0,0 +1,123 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"errors"
+	"github.com/ExpediaGroup/flyte-slack/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestScheduleMessageCommandIsPopulated(t *testing.T) {
+	command := ScheduleMessage(nil)
+
+	assert.Equal(t, "ScheduleMessage", command.Name)
+	require.Equal(t, 2, len(command.OutputEvents))
+	assert.Equal(t, "MessageScheduled", command.OutputEvents[0].Name)
+	assert.Equal(t, "ScheduleMessageFailed", command.OutputEvents[1].Name)
+}
+
+func TestScheduleMessageAcceptsUnixTime(t *testing.T) {
+	slack := NewMockSlack()
+	var gotPostAt time.Time
+	slack.ScheduleMessageFunc = func(message, channelId, threadTimestamp string, postAt time.Time) (string, error) {
+		gotPostAt = postAt
+		return "Q1298393284", nil
+	}
+
+	event := ScheduleMessage(slack).Handler([]byte(`{"message": "release checklist", "channelId": "C123", "postAt": "1792486800"}`))
+
+	require.Equal(t, messageScheduledEventDef, event.EventDef)
+	output := event.Payload.(ScheduleMessageSuccess)
+	assert.Equal(t, "Q1298393284", output.ScheduledMessageId)
+	assert.Equal(t, "C123", output.ChannelId)
+	assert.Equal(t, int64(1792486800), gotPostAt.Unix())
+}
+
+func TestScheduleMessageAcceptsRFC3339Timestamp(t *testing.T) {
+	slack := NewMockSlack()
+	var gotPostAt time.Time
+	slack.ScheduleMessageFunc = func(message, channelId, threadTimestamp string, postAt time.Time) (string, error) {
+		gotPostAt = postAt
+		return "Q1298393284", nil
+	}
+
+	event := ScheduleMessage(slack).Handler([]byte(`{"message": "release checklist", "channelId": "C123", "postAt": "2026-10-20T10:00:00+01:00"}`))
+
+	require.Equal(t, messageScheduledEventDef, event.EventDef)
+	assert.Equal(t, int64(1792486800), gotPostAt.Unix())
+}
+
+func TestScheduleMessageHandleInvalidInput(t *testing.T) {
+	event := ScheduleMessage(NewMockSlack()).Handler([]byte(`{"postAt": "tomorrow"}`))
+
+	require.Equal(t, scheduleMessageFailedEventDef, event.EventDef)
+	output := event.Payload.(ScheduleMessageFail)
+	assert.Equal(t, `missing message field, missing channel id field, post at="tomorrow" is neither unix time nor RFC3339 timestamp`, output.Reason)
+}
+
+func TestScheduleMessageReturnsFailedEventOnSlackError(t *testing.T) {
+	slack := NewMockSlack()
+	slack.ScheduleMessageFunc = func(message, channelId, threadTimestamp string, postAt time.Time) (string, error) {
+		return "", errors.New("time_in_past")
+	}
+
+	event := ScheduleMessage(slack).Handler([]byte(`{"message": "hi", "channelId": "C123", "postAt": "1"}`))
+
+	require.Equal(t, scheduleMessageFailedEventDef, event.EventDef)
+	assert.Equal(t, "time_in_past", event.Payload.(ScheduleMessageFail).Reason)
+}
+
+func TestListScheduledMessagesReturnsMessages(t *testing.T) {
+	slack := NewMockSlack()
+	slack.ListScheduledMessagesFunc = func(channelId string) ([]types.ScheduledMessage, error) {
+		return []types.ScheduledMessage{{ID: "Q1", ChannelID: channelId, PostAt: 1792486800, Text: "hi"}}, nil
+	}
+
+	event := ListScheduledMessages(slack).Handler([]byte(`{"channelId": "C123"}`))
+
+	require.Equal(t, scheduledMessagesListedEventDef, event.EventDef)
+	output := event.Payload.(ListScheduledMessagesSuccess)
+	require.Len(t, output.ScheduledMessages, 1)
+	assert.Equal(t, "Q1", output.ScheduledMessages[0].ID)
+	assert.Equal(t, "C123", output.ScheduledMessages[0].ChannelID)
+}
+
+func TestDeleteScheduledMessageDeletesMessage(t *testing.T) {
+	slack := NewMockSlack()
+	var gotChannel, gotId string
+	slack.DeleteScheduledMessageFunc = func(channelId, scheduledMessageId string) error {
+		gotChannel, gotId = channelId, scheduledMessageId
+		return nil
+	}
+
+	event := DeleteScheduledMessage(slack).Handler([]byte(`{"channelId": "C123", "scheduledMessageId": "Q1"}`))
+
+	require.Equal(t, scheduledMessageDeletedEventDef, event.EventDef)
+	assert.Equal(t, "C123", gotChannel)
+	assert.Equal(t, "Q1", gotId)
+}
+
+func TestDeleteScheduledMessageHandleInputWithMissingFields(t *testing.T) {
+	event := DeleteScheduledMessage(NewMockSlack()).Handler([]byte(`{}`))
+
+	require.Equal(t, deleteScheduledMessageFailedEventDef, event.EventDef)
+	assert.Equal(t, "missing channel id field, missing scheduled message id field", event.Payload.(DeleteScheduledMessageFail).Reason)
+}
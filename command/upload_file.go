@@ -0,0 +1,205 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ExpediaGroup/flyte-client/flyte"
+	"github.com/ExpediaGroup/flyte-slack/client"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	fileUploadedEventDef     = flyte.EventDef{Name: "FileUploaded"}
+	uploadFileFailedEventDef = flyte.EventDef{Name: "UploadFileFailed"}
+)
+
+const (
+	contentEncodingText   = "text"
+	contentEncodingBase64 = "base64"
+)
+
+type UploadFileInput struct {
+	// Content is uploaded as is, if empty Filename is read from upload directory
+	Content string `json:"content"`
+	// ContentEncoding is either "text" (default) or "base64"
+	ContentEncoding string   `json:"contentEncoding"`
+	Filename        string   `json:"filename"`
+	Filetype        string   `json:"filetype"`
+	Title           string   `json:"title"`
+	InitialComment  string   `json:"initialComment"`
+	ChannelIds      []string `json:"channelIds"`
+	ThreadTimestamp string   `json:"threadTimestamp"`
+}
+
+// UploadFileOutput is the command input without content, which might be large
+type UploadFileOutput struct {
+	Filename        string   `json:"filename"`
+	Filetype        string   `json:"filetype"`
+	Title           string   `json:"title"`
+	ChannelIds      []string `json:"channelIds"`
+	ThreadTimestamp string   `json:"threadTimestamp"`
+}
+
+type UploadFileSuccess struct {
+	UploadFileOutput
+	FileId    string `json:"fileId"`
+	Permalink string `json:"permalink"`
+}
+
+type UploadFileFail struct {
+	UploadFileOutput
+	Reason string `json:"reason"`
+}
+
+// UploadFile uploads inline content or a file from uploadDir. Local files are disabled when uploadDir is empty.
+func UploadFile(slack client.Slack, uploadDir string) flyte.Command {
+	return flyte.Command{
+		Name:         "UploadFile",
+		OutputEvents: []flyte.EventDef{fileUploadedEventDef, uploadFileFailedEventDef},
+		Handler:      uploadFileHandler(slack, uploadDir),
+	}
+}
+
+func uploadFileHandler(slack client.Slack, uploadDir string) func(json.RawMessage) flyte.Event {
+	return func(rawInput json.RawMessage) flyte.Event {
+		input := UploadFileInput{}
+		if err := json.Unmarshal(rawInput, &input); err != nil {
+			return flyte.NewFatalEvent(fmt.Sprintf("input is not valid: %v", err))
+		}
+
+		errorMessages := []string{}
+		if input.Content == "" && input.Filename == "" {
+			errorMessages = append(errorMessages, "missing content or filename field")
+		}
+		if len(input.ChannelIds) == 0 {
+			errorMessages = append(errorMessages, "missing channel ids field")
+		}
+		if len(errorMessages) != 0 {
+			return newUploadFileFail(input, strings.Join(errorMessages, ", "))
+		}
+
+		r, err := uploadFileReader(input, uploadDir)
+		if err != nil {
+			return newUploadFileFail(input, err.Error())
+		}
+		defer r.Close()
+
+		fileId, permalink, err := slack.UploadFile(client.FileUpload{
+			Reader:          r,
+			Filename:        uploadFilename(input),
+			Filetype:        input.Filetype,
+			Title:           input.Title,
+			InitialComment:  input.InitialComment,
+			ChannelIds:      input.ChannelIds,
+			ThreadTimestamp: input.ThreadTimestamp,
+		})
+		if err != nil {
+			return newUploadFileFail(input, err.Error())
+		}
+
+		return flyte.Event{
+			EventDef: fileUploadedEventDef,
+			Payload: UploadFileSuccess{
+				UploadFileOutput: newUploadFileOutput(input),
+				FileId:           fileId,
+				Permalink:        permalink,
+			},
+		}
+	}
+}
+
+func uploadFileReader(input UploadFileInput, uploadDir string) (io.ReadCloser, error) {
+	if input.Content == "" {
+		return openUploadFile(uploadDir, input.Filename)
+	}
+
+	switch input.ContentEncoding {
+	case "", contentEncodingText:
+		return ioutil.NopCloser(strings.NewReader(input.Content)), nil
+	case contentEncodingBase64:
+		return ioutil.NopCloser(base64.NewDecoder(base64.StdEncoding, strings.NewReader(input.Content))), nil
+	default:
+		return nil, fmt.Errorf("unknown content encoding=%q, expected %q or %q", input.ContentEncoding, contentEncodingText, contentEncodingBase64)
+	}
+}
+
+// openUploadFile opens filename relative to uploadDir, refusing anything that resolves outside of it
+func openUploadFile(uploadDir, filename string) (*os.File, error) {
+	if uploadDir == "" {
+		return nil, errors.New("uploading local files is not enabled")
+	}
+
+	root, err := filepath.EvalSymlinks(uploadDir)
+	if err != nil {
+		return nil, fmt.Errorf("invalid upload directory: %v", err)
+	}
+
+	path, err := filepath.EvalSymlinks(filepath.Join(root, filename))
+	if err != nil {
+		return nil, fmt.Errorf("cannot open file=%q: %v", filename, err)
+	}
+
+	rel, err := filepath.Rel(root, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil, fmt.Errorf("file=%q is outside of upload directory", filename)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open file=%q: %v", filename, err)
+	}
+	if fi, err := f.Stat(); err != nil || !fi.Mode().IsRegular() {
+		f.Close()
+		return nil, fmt.Errorf("file=%q is not a regular file", filename)
+	}
+	return f, nil
+}
+
+func uploadFilename(input UploadFileInput) string {
+	if input.Filename != "" {
+		return filepath.Base(input.Filename)
+	}
+	return "file"
+}
+
+func newUploadFileOutput(input UploadFileInput) UploadFileOutput {
+	return UploadFileOutput{
+		Filename:        input.Filename,
+		Filetype:        input.Filetype,
+		Title:           input.Title,
+		ChannelIds:      input.ChannelIds,
+		ThreadTimestamp: input.ThreadTimestamp,
+	}
+}
+
+func newUploadFileFail(input UploadFileInput, reason string) flyte.Event {
+	return flyte.Event{
+		EventDef: uploadFileFailedEventDef,
+		Payload: UploadFileFail{
+			UploadFileOutput: newUploadFileOutput(input),
+			Reason:           reason,
+		},
+	}
+}
@@ -0,0 +1,137 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"github.com/ExpediaGroup/flyte-slack/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestUploadFileCommandIsPopulated(t *testing.T) {
+	command := UploadFile(nil, "")
+
+	assert.Equal(t, "UploadFile", command.Name)
+	require.Equal(t, 2, len(command.OutputEvents))
+	assert.Equal(t, "FileUploaded", command.OutputEvents[0].Name)
+	assert.Equal(t, "UploadFileFailed", command.OutputEvents[1].Name)
+}
+
+func TestUploadFileUploadsContent(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "plain text", input: `{"content": "stack trace", "channelIds": ["C123"], "threadTimestamp": "123.4"}`},
+		{name: "base64", input: `{"content": "c3RhY2sgdHJhY2U=", "contentEncoding": "base64", "channelIds": ["C123"], "threadTimestamp": "123.4"}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			slack, uploaded := newUploadFileMockSlack(t)
+
+			event := UploadFile(slack, "").Handler([]byte(test.input))
+
+			require.Equal(t, fileUploadedEventDef, event.EventDef)
+			output := event.Payload.(UploadFileSuccess)
+			assert.Equal(t, "F123", output.FileId)
+			assert.Equal(t, "https://example.slack.com/files/F123", output.Permalink)
+			assert.Equal(t, "stack trace", uploaded.content)
+			assert.Equal(t, "file", uploaded.FileUpload.Filename)
+			assert.Equal(t, []string{"C123"}, uploaded.FileUpload.ChannelIds)
+			assert.Equal(t, "123.4", uploaded.FileUpload.ThreadTimestamp)
+		})
+	}
+}
+
+func TestUploadFileUploadsFileFromUploadDir(t *testing.T) {
+	dir := newUploadDir(t)
+	slack, uploaded := newUploadFileMockSlack(t)
+
+	event := UploadFile(slack, dir).Handler([]byte(`{"filename": "reports/report.csv", "channelIds": ["C123"]}`))
+
+	require.Equal(t, fileUploadedEventDef, event.EventDef)
+	assert.Equal(t, "a,b,c", uploaded.content)
+	assert.Equal(t, "report.csv", uploaded.FileUpload.Filename)
+}
+
+func TestUploadFileRejectsFilesOutsideOfUploadDir(t *testing.T) {
+	dir := newUploadDir(t)
+
+	tests := []struct {
+		name      string
+		uploadDir string
+		filename  string
+		reason    string
+	}{
+		{name: "disabled", uploadDir: "", filename: "reports/report.csv", reason: "uploading local files is not enabled"},
+		{name: "parent directory", uploadDir: filepath.Join(dir, "reports"), filename: "../secret.txt", reason: `file="../secret.txt" is outside of upload directory`},
+		{name: "symlink", uploadDir: filepath.Join(dir, "reports"), filename: "link.txt", reason: `file="link.txt" is outside of upload directory`},
+		{name: "directory", uploadDir: dir, filename: "reports", reason: `file="reports" is not a regular file`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			event := UploadFile(NewMockSlack(), test.uploadDir).Handler([]byte(`{"filename": "` + test.filename + `", "channelIds": ["C123"]}`))
+
+			require.Equal(t, uploadFileFailedEventDef, event.EventDef)
+			assert.Equal(t, test.reason, event.Payload.(UploadFileFail).Reason)
+		})
+	}
+}
+
+func TestUploadFileHandleInputWithMissingFields(t *testing.T) {
+	event := UploadFile(NewMockSlack(), "").Handler([]byte(`{}`))
+
+	require.Equal(t, uploadFileFailedEventDef, event.EventDef)
+	assert.Equal(t, "missing content or filename field, missing channel ids field", event.Payload.(UploadFileFail).Reason)
+}
+
+type uploadedFile struct {
+	client.FileUpload
+	content string
+}
+
+func newUploadFileMockSlack(t *testing.T) (*MockSlack, *uploadedFile) {
+	uploaded := &uploadedFile{}
+	slack := NewMockSlack()
+	slack.UploadFileFunc = func(f client.FileUpload) (string, string, error) {
+		b, err := ioutil.ReadAll(f.Reader)
+		require.NoError(t, err)
+		uploaded.FileUpload = f
+		uploaded.content = string(b)
+		return "F123", "https://example.slack.com/files/F123", nil
+	}
+	return slack, uploaded
+}
+
+// newUploadDir creates dir/secret.txt, dir/reports/report.csv and dir/reports/link.txt -> dir/secret.txt
+func newUploadDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "flyte-slack-upload")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "reports"), 0700))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "secret.txt"), []byte("secret"), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "reports", "report.csv"), []byte("a,b,c"), 0600))
+	require.NoError(t, os.Symlink(filepath.Join(dir, "secret.txt"), filepath.Join(dir, "reports", "link.txt")))
+	return dir
+}
@@ -0,0 +1,321 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ExpediaGroup/flyte-client/flyte"
+	"github.com/ExpediaGroup/flyte-slack/cache"
+	"github.com/ExpediaGroup/flyte-slack/client"
+	"github.com/ExpediaGroup/flyte-slack/types"
+	"strings"
+)
+
+var (
+	listUserGroupsSuccessEventDef         = flyte.EventDef{Name: "ListUserGroupsSuccess"}
+	listUserGroupsFailedEventDef          = flyte.EventDef{Name: "ListUserGroupsFailed"}
+	getUserGroupMembersSuccessEventDef    = flyte.EventDef{Name: "GetUserGroupMembersSuccess"}
+	getUserGroupMembersFailedEventDef     = flyte.EventDef{Name: "GetUserGroupMembersFailed"}
+	updateUserGroupMembersSuccessEventDef = flyte.EventDef{Name: "UpdateUserGroupMembersSuccess"}
+	updateUserGroupMembersFailedEventDef  = flyte.EventDef{Name: "UpdateUserGroupMembersFailed"}
+	createUserGroupSuccessEventDef        = flyte.EventDef{Name: "CreateUserGroupSuccess"}
+	createUserGroupFailedEventDef         = flyte.EventDef{Name: "CreateUserGroupFailed"}
+	disableUserGroupSuccessEventDef       = flyte.EventDef{Name: "DisableUserGroupSuccess"}
+	disableUserGroupFailedEventDef        = flyte.EventDef{Name: "DisableUserGroupFailed"}
+)
+
+type ListUserGroupsInput struct {
+	IncludeDisabled bool `json:"includeDisabled"`
+}
+
+type ListUserGroupsSuccess struct {
+	ListUserGroupsInput
+	UserGroups []types.UserGroup `json:"userGroups"`
+}
+
+type ListUserGroupsFail struct {
+	ListUserGroupsInput
+	Reason string `json:"reason"`
+}
+
+// UserGroupInput identifies user group by id or handle, id is filled in when user group is given by handle
+type UserGroupInput struct {
+	UserGroupId     string `json:"userGroupId"`
+	UserGroupHandle string `json:"userGroupHandle"`
+}
+
+type GetUserGroupMembersSuccess struct {
+	UserGroupInput
+	MemberIds []string `json:"memberIds"`
+}
+
+// UserGroupSuccess is returned by commands changing the user group
+type UserGroupSuccess struct {
+	UserGroupInput
+	UserGroup *types.UserGroup `json:"userGroup"`
+}
+
+type UserGroupFail struct {
+	UserGroupInput
+	Reason string `json:"reason"`
+}
+
+type UpdateUserGroupMembersInput struct {
+	UserGroupInput
+	UserIds []string `json:"userIds"`
+	Emails  []string `json:"emails"`
+}
+
+type UpdateUserGroupMembersSuccess struct {
+	UpdateUserGroupMembersInput
+	UserGroup *types.UserGroup `json:"userGroup"`
+}
+
+type UpdateUserGroupMembersFail struct {
+	UpdateUserGroupMembersInput
+	Reason string `json:"reason"`
+}
+
+type CreateUserGroupInput struct {
+	Name        string `json:"name"`
+	Handle      string `json:"handle"`
+	Description string `json:"description"`
+}
+
+type CreateUserGroupSuccess struct {
+	CreateUserGroupInput
+	UserGroup *types.UserGroup `json:"userGroup"`
+}
+
+type CreateUserGroupFail struct {
+	CreateUserGroupInput
+	Reason string `json:"reason"`
+}
+
+func ListUserGroups(slack client.Slack) flyte.Command {
+	return flyte.Command{
+		Name:         "ListUserGroups",
+		OutputEvents: []flyte.EventDef{listUserGroupsSuccessEventDef, listUserGroupsFailedEventDef},
+		Handler:      listUserGroupsHandler(slack),
+	}
+}
+
+func listUserGroupsHandler(slack client.Slack) func(json.RawMessage) flyte.Event {
+	return func(rawInput json.RawMessage) flyte.Event {
+		input := ListUserGroupsInput{}
+		if err := json.Unmarshal(rawInput, &input); err != nil {
+			return flyte.NewFatalEvent(fmt.Sprintf("input is not valid: %v", err))
+		}
+
+		groups, err := slack.ListUserGroups(input.IncludeDisabled)
+		if err != nil {
+			return flyte.Event{
+				EventDef: listUserGroupsFailedEventDef,
+				Payload:  ListUserGroupsFail{ListUserGroupsInput: input, Reason: err.Error()},
+			}
+		}
+
+		return flyte.Event{
+			EventDef: listUserGroupsSuccessEventDef,
+			Payload:  ListUserGroupsSuccess{ListUserGroupsInput: input, UserGroups: groups},
+		}
+	}
+}
+
+func GetUserGroupMembers(slack client.Slack, cache cache.Cache) flyte.Command {
+	return flyte.Command{
+		Name:         "GetUserGroupMembers",
+		OutputEvents: []flyte.EventDef{getUserGroupMembersSuccessEventDef, getUserGroupMembersFailedEventDef},
+		Handler:      getUserGroupMembersHandler(slack, cache),
+	}
+}
+
+func getUserGroupMembersHandler(slack client.Slack, cache cache.Cache) func(json.RawMessage) flyte.Event {
+	return func(rawInput json.RawMessage) flyte.Event {
+		input := UserGroupInput{}
+		if err := json.Unmarshal(rawInput, &input); err != nil {
+			return flyte.NewFatalEvent(fmt.Sprintf("input is not valid: %v", err))
+		}
+
+		if err := resolveUserGroupId(&input, slack, cache); err != nil {
+			return newUserGroupFail(getUserGroupMembersFailedEventDef, input, err.Error())
+		}
+
+		members, err := slack.GetUserGroupMembers(input.UserGroupId)
+		if err != nil {
+			return newUserGroupFail(getUserGroupMembersFailedEventDef, input, fmt.Sprintf("cannot get members of user group=%s: %v", input.UserGroupId, err))
+		}
+
+		return flyte.Event{
+			EventDef: getUserGroupMembersSuccessEventDef,
+			Payload:  GetUserGroupMembersSuccess{UserGroupInput: input, MemberIds: members},
+		}
+	}
+}
+
+// UpdateUserGroupMembers replaces members of the user group, nothing is changed when any of the users can't be resolved
+func UpdateUserGroupMembers(slack client.Slack, cache cache.Cache) flyte.Command {
+	return flyte.Command{
+		Name:         "UpdateUserGroupMembers",
+		OutputEvents: []flyte.EventDef{updateUserGroupMembersSuccessEventDef, updateUserGroupMembersFailedEventDef},
+		Handler:      updateUserGroupMembersHandler(slack, cache),
+	}
+}
+
+func updateUserGroupMembersHandler(slack client.Slack, cache cache.Cache) func(json.RawMessage) flyte.Event {
+	return func(rawInput json.RawMessage) flyte.Event {
+		input := UpdateUserGroupMembersInput{}
+		if err := json.Unmarshal(rawInput, &input); err != nil {
+			return flyte.NewFatalEvent(fmt.Sprintf("input is not valid: %v", err))
+		}
+
+		if len(input.UserIds) == 0 && len(input.Emails) == 0 {
+			return newUpdateUserGroupMembersFail(input, "missing user ids or emails field")
+		}
+		if err := resolveUserGroupId(&input.UserGroupInput, slack, cache); err != nil {
+			return newUpdateUserGroupMembersFail(input, err.Error())
+		}
+
+		userIds, failed := resolveUserIds(slack, cache, input.UserIds, input.Emails, nil)
+		if len(failed) != 0 {
+			reasons := []string{}
+			for _, f := range failed {
+				reasons = append(reasons, fmt.Sprintf("%s: %s", f.User, f.Reason))
+			}
+			return newUpdateUserGroupMembersFail(input, "cannot resolve users "+strings.Join(reasons, ", "))
+		}
+
+		g, err := slack.UpdateUserGroupMembers(input.UserGroupId, userIds)
+		if err != nil {
+			return newUpdateUserGroupMembersFail(input, err.Error())
+		}
+
+		return flyte.Event{
+			EventDef: updateUserGroupMembersSuccessEventDef,
+			Payload:  UpdateUserGroupMembersSuccess{UpdateUserGroupMembersInput: input, UserGroup: g},
+		}
+	}
+}
+
+func newUpdateUserGroupMembersFail(input UpdateUserGroupMembersInput, reason string) flyte.Event {
+	return flyte.Event{
+		EventDef: updateUserGroupMembersFailedEventDef,
+		Payload:  UpdateUserGroupMembersFail{UpdateUserGroupMembersInput: input, Reason: reason},
+	}
+}
+
+func CreateUserGroup(slack client.Slack) flyte.Command {
+	return flyte.Command{
+		Name:         "CreateUserGroup",
+		OutputEvents: []flyte.EventDef{createUserGroupSuccessEventDef, createUserGroupFailedEventDef},
+		Handler:      createUserGroupHandler(slack),
+	}
+}
+
+func createUserGroupHandler(slack client.Slack) func(json.RawMessage) flyte.Event {
+	return func(rawInput json.RawMessage) flyte.Event {
+		input := CreateUserGroupInput{}
+		if err := json.Unmarshal(rawInput, &input); err != nil {
+			return flyte.NewFatalEvent(fmt.Sprintf("input is not valid: %v", err))
+		}
+
+		errorMessages := []string{}
+		if input.Name == "" {
+			errorMessages = append(errorMessages, "missing name field")
+		}
+		if input.Handle == "" {
+			errorMessages = append(errorMessages, "missing handle field")
+		}
+		if len(errorMessages) != 0 {
+			return newCreateUserGroupFail(input, strings.Join(errorMessages, ", "))
+		}
+
+		g, err := slack.CreateUserGroup(input.Name, strings.TrimPrefix(input.Handle, "@"), input.Description)
+		if err != nil {
+			return newCreateUserGroupFail(input, err.Error())
+		}
+
+		return flyte.Event{
+			EventDef: createUserGroupSuccessEventDef,
+			Payload:  CreateUserGroupSuccess{CreateUserGroupInput: input, UserGroup: g},
+		}
+	}
+}
+
+func newCreateUserGroupFail(input CreateUserGroupInput, reason string) flyte.Event {
+	return flyte.Event{
+		EventDef: createUserGroupFailedEventDef,
+		Payload:  CreateUserGroupFail{CreateUserGroupInput: input, Reason: reason},
+	}
+}
+
+func DisableUserGroup(slack client.Slack, cache cache.Cache) flyte.Command {
+	return flyte.Command{
+		Name:         "DisableUserGroup",
+		OutputEvents: []flyte.EventDef{disableUserGroupSuccessEventDef, disableUserGroupFailedEventDef},
+		Handler:      disableUserGroupHandler(slack, cache),
+	}
+}
+
+func disableUserGroupHandler(slack client.Slack, cache cache.Cache) func(json.RawMessage) flyte.Event {
+	return func(rawInput json.RawMessage) flyte.Event {
+		input := UserGroupInput{}
+		if err := json.Unmarshal(rawInput, &input); err != nil {
+			return flyte.NewFatalEvent(fmt.Sprintf("input is not valid: %v", err))
+		}
+
+		if err := resolveUserGroupId(&input, slack, cache); err != nil {
+			return newUserGroupFail(disableUserGroupFailedEventDef, input, err.Error())
+		}
+
+		g, err := slack.DisableUserGroup(input.UserGroupId)
+		if err != nil {
+			return newUserGroupFail(disableUserGroupFailedEventDef, input, err.Error())
+		}
+
+		return flyte.Event{
+			EventDef: disableUserGroupSuccessEventDef,
+			Payload:  UserGroupSuccess{UserGroupInput: input, UserGroup: g},
+		}
+	}
+}
+
+// resolveUserGroupId looks up user group id by handle when only handle is given
+func resolveUserGroupId(input *UserGroupInput, slack client.Slack, cache cache.Cache) error {
+	if input.UserGroupId != "" {
+		return nil
+	}
+	if input.UserGroupHandle == "" {
+		return errors.New("missing user group id or user group handle field")
+	}
+
+	g, err := cache.GetUserGroup(strings.TrimPrefix(input.UserGroupHandle, "@"), slack)
+	if err != nil {
+		return fmt.Errorf("cannot find user group=%s: %v", input.UserGroupHandle, err)
+	}
+	input.UserGroupId = g.ID
+	return nil
+}
+
+func newUserGroupFail(def flyte.EventDef, input UserGroupInput, reason string) flyte.Event {
+	return flyte.Event{
+		EventDef: def,
+		Payload:  UserGroupFail{UserGroupInput: input, Reason: reason},
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"errors"
+	"github.com/ExpediaGroup/flyte-slack/client"
+	"github.com/ExpediaGroup/flyte-slack/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func newUserGroupsMockSlack() *MockSlack {
+	slack := NewMockSlack()
+	slack.GetUserGroupsFunc = func() ([]types.UserGroup, error) {
+		return []types.UserGroup{{ID: "S1", Name: "SRE on call", Handle: "sre-oncall"}}, nil
+	}
+	return slack
+}
+
+func TestListUserGroups(t *testing.T) {
+	slack := NewMockSlack()
+	slack.ListUserGroupsFunc = func(includeDisabled bool) ([]types.UserGroup, error) {
+		assert.True(t, includeDisabled)
+		return []types.UserGroup{{ID: "S1", Handle: "sre-oncall", UserCount: 3}}, nil
+	}
+
+	event := ListUserGroups(slack).Handler([]byte(`{"includeDisabled": true}`))
+
+	require.Equal(t, listUserGroupsSuccessEventDef, event.EventDef)
+	assert.Equal(t, 3, event.Payload.(ListUserGroupsSuccess).UserGroups[0].UserCount)
+}
+
+func TestGetUserGroupMembersByHandle(t *testing.T) {
+	slack := newUserGroupsMockSlack()
+	slack.GetUserGroupMembersFunc = func(userGroupId string) ([]string, error) {
+		assert.Equal(t, "S1", userGroupId)
+		return []string{"U1", "U2"}, nil
+	}
+
+	event := GetUserGroupMembers(slack, newTestCache()).Handler([]byte(`{"userGroupHandle": "@sre-oncall"}`))
+
+	require.Equal(t, getUserGroupMembersSuccessEventDef, event.EventDef)
+	output := event.Payload.(GetUserGroupMembersSuccess)
+	assert.Equal(t, "S1", output.UserGroupId)
+	assert.Equal(t, []string{"U1", "U2"}, output.MemberIds)
+}
+
+func TestGetUserGroupMembersFailsForUnknownHandle(t *testing.T) {
+	event := GetUserGroupMembers(newUserGroupsMockSlack(), newTestCache()).Handler([]byte(`{"userGroupHandle": "dba"}`))
+
+	require.Equal(t, getUserGroupMembersFailedEventDef, event.EventDef)
+	assert.Contains(t, event.Payload.(UserGroupFail).Reason, "cannot find user group=dba")
+}
+
+func TestUpdateUserGroupMembers(t *testing.T) {
+	slack := newUserGroupsMockSlack()
+	slack.LookupUserByEmailFunc = func(email string) (*client.User, error) {
+		return &client.User{Id: "U2"}, nil
+	}
+	slack.UpdateUserGroupMembersFunc = func(userGroupId string, userIds []string) (*types.UserGroup, error) {
+		return &types.UserGroup{ID: userGroupId, Handle: "sre-oncall", Users: userIds}, nil
+	}
+
+	event := UpdateUserGroupMembers(slack, newTestCache()).Handler([]byte(`{"userGroupHandle": "sre-oncall", "userIds": ["U1"], "emails": ["jdoe@example.com"]}`))
+
+	require.Equal(t, updateUserGroupMembersSuccessEventDef, event.EventDef)
+	assert.Equal(t, []string{"U1", "U2"}, event.Payload.(UpdateUserGroupMembersSuccess).UserGroup.Users)
+}
+
+func TestUpdateUserGroupMembersFailsWhenUserCannotBeResolved(t *testing.T) {
+	slack := newUserGroupsMockSlack()
+	slack.LookupUserByEmailFunc = func(email string) (*client.User, error) {
+		return nil, errors.New("users_not_found")
+	}
+	slack.UpdateUserGroupMembersFunc = func(userGroupId string, userIds []string) (*types.UserGroup, error) {
+		assert.Fail(t, "members shouldn't be updated")
+		return nil, nil
+	}
+
+	event := UpdateUserGroupMembers(slack, newTestCache()).Handler([]byte(`{"userGroupId": "S1", "userIds": ["U1"], "emails": ["nobody@example.com"]}`))
+
+	require.Equal(t, updateUserGroupMembersFailedEventDef, event.EventDef)
+	assert.Contains(t, event.Payload.(UpdateUserGroupMembersFail).Reason, "cannot resolve users nobody@example.com")
+}
+
+func TestUpdateUserGroupMembersFailsWithoutUsers(t *testing.T) {
+	event := UpdateUserGroupMembers(NewMockSlack(), newTestCache()).Handler([]byte(`{"userGroupId": "S1"}`))
+
+	require.Equal(t, updateUserGroupMembersFailedEventDef, event.EventDef)
+	assert.Equal(t, "missing user ids or emails field", event.Payload.(UpdateUserGroupMembersFail).Reason)
+}
+
+func TestCreateUserGroup(t *testing.T) {
+	slack := NewMockSlack()
+	slack.CreateUserGroupFunc = func(name, handle, description string) (*types.UserGroup, error) {
+		return &types.UserGroup{ID: "S2", Name: name, Handle: handle, Description: description}, nil
+	}
+
+	event := CreateUserGroup(slack).Handler([]byte(`{"name": "DBA", "handle": "@dba", "description": "Database admins"}`))
+
+	require.Equal(t, createUserGroupSuccessEventDef, event.EventDef)
+	assert.Equal(t, "dba", event.Payload.(CreateUserGroupSuccess).UserGroup.Handle)
+}
+
+func TestCreateUserGroupFailsWithoutNameAndHandle(t *testing.T) {
+	event := CreateUserGroup(NewMockSlack()).Handler([]byte(`{}`))
+
+	require.Equal(t, createUserGroupFailedEventDef, event.EventDef)
+	assert.Equal(t, "missing name field, missing handle field", event.Payload.(CreateUserGroupFail).Reason)
+}
+
+func TestDisableUserGroup(t *testing.T) {
+	slack := newUserGroupsMockSlack()
+	slack.DisableUserGroupFunc = func(userGroupId string) (*types.UserGroup, error) {
+		return &types.UserGroup{ID: userGroupId, IsDisabled: true}, nil
+	}
+
+	event := DisableUserGroup(slack, newTestCache()).Handler([]byte(`{"userGroupHandle": "sre-oncall"}`))
+
+	require.Equal(t, disableUserGroupSuccessEventDef, event.EventDef)
+	assert.True(t, event.Payload.(UserGroupSuccess).UserGroup.IsDisabled)
+}
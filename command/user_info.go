@@ -0,0 +1,147 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/ExpediaGroup/flyte-client/flyte"
+	"github.com/ExpediaGroup/flyte-slack/client"
+)
+
+var (
+	getUserInfoSuccessEventDef       = flyte.EventDef{Name: "GetUserInfoSuccess"}
+	getUserInfoFailedEventDef        = flyte.EventDef{Name: "GetUserInfoFailed"}
+	lookupUserByEmailSuccessEventDef = flyte.EventDef{Name: "LookupUserByEmailSuccess"}
+	lookupUserByEmailFailedEventDef  = flyte.EventDef{Name: "LookupUserByEmailFailed"}
+)
+
+type GetUserInfoInput struct {
+	UserId string `json:"userId"`
+}
+
+type GetUserInfoSuccess struct {
+	GetUserInfoInput
+	User *client.User `json:"user"`
+}
+
+type GetUserInfoFail struct {
+	GetUserInfoInput
+	Reason string `json:"reason"`
+}
+
+type LookupUserByEmailInput struct {
+	Email string `json:"email"`
+}
+
+type LookupUserByEmailSuccess struct {
+	LookupUserByEmailInput
+	User *client.User `json:"user"`
+}
+
+type LookupUserByEmailFail struct {
+	LookupUserByEmailInput
+	Reason string `json:"reason"`
+}
+
+func GetUserInfo(slack client.Slack) flyte.Command {
+	return flyte.Command{
+		Name:         "GetUserInfo",
+		OutputEvents: []flyte.EventDef{getUserInfoSuccessEventDef, getUserInfoFailedEventDef},
+		Handler:      getUserInfoHandler(slack),
+	}
+}
+
+func getUserInfoHandler(slack client.Slack) func(json.RawMessage) flyte.Event {
+	return func(rawInput json.RawMessage) flyte.Event {
+		input := GetUserInfoInput{}
+		if err := json.Unmarshal(rawInput, &input); err != nil {
+			return flyte.NewFatalEvent(fmt.Sprintf("input is not valid: %v", err))
+		}
+
+		if input.UserId == "" {
+			return newGetUserInfoFail(input, "missing user id field")
+		}
+
+		u, err := slack.GetUserInfo(input.UserId)
+		if err != nil {
+			return newGetUserInfoFail(input, err.Error())
+		}
+
+		return flyte.Event{
+			EventDef: getUserInfoSuccessEventDef,
+			Payload: GetUserInfoSuccess{
+				GetUserInfoInput: input,
+				User:             u,
+			},
+		}
+	}
+}
+
+func newGetUserInfoFail(input GetUserInfoInput, reason string) flyte.Event {
+	return flyte.Event{
+		EventDef: getUserInfoFailedEventDef,
+		Payload: GetUserInfoFail{
+			GetUserInfoInput: input,
+			Reason:           reason,
+		},
+	}
+}
+
+func LookupUserByEmail(slack client.Slack) flyte.Command {
+	return flyte.Command{
+		Name:         "LookupUserByEmail",
+		OutputEvents: []flyte.EventDef{lookupUserByEmailSuccessEventDef, lookupUserByEmailFailedEventDef},
+		Handler:      lookupUserByEmailHandler(slack),
+	}
+}
+
+func lookupUserByEmailHandler(slack client.Slack) func(json.RawMessage) flyte.Event {
+	return func(rawInput json.RawMessage) flyte.Event {
+		input := LookupUserByEmailInput{}
+		if err := json.Unmarshal(rawInput, &input); err != nil {
+			return flyte.NewFatalEvent(fmt.Sprintf("input is not valid: %v", err))
+		}
+
+		if input.Email == "" {
+			return newLookupUserByEmailFail(input, "missing email field")
+		}
+
+		u, err := slack.LookupUserByEmail(input.Email)
+		if err != nil {
+			return newLookupUserByEmailFail(input, err.Error())
+		}
+
+		return flyte.Event{
+			EventDef: lookupUserByEmailSuccessEventDef,
+			Payload: LookupUserByEmailSuccess{
+				LookupUserByEmailInput: input,
+				User:                   u,
+			},
+		}
+	}
+}
+
+func newLookupUserByEmailFail(input LookupUserByEmailInput, reason string) flyte.Event {
+	return flyte.Event{
+		EventDef: lookupUserByEmailFailedEventDef,
+		Payload: LookupUserByEmailFail{
+			LookupUserByEmailInput: input,
+			Reason:                 reason,
+		},
+	}
+}
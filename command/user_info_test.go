@@ -0,0 +1,70 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"errors"
+	"github.com/ExpediaGroup/flyte-slack/client"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestGetUserInfo(t *testing.T) {
+	slack := NewMockSlack()
+	slack.GetUserInfoFunc = func(userId string) (*client.User, error) {
+		return &client.User{Id: userId, Name: "jdoe", Timezone: "Europe/London", IsAdmin: true}, nil
+	}
+
+	event := GetUserInfo(slack).Handler([]byte(`{"userId": "U1"}`))
+
+	require.Equal(t, getUserInfoSuccessEventDef, event.EventDef)
+	output := event.Payload.(GetUserInfoSuccess)
+	assert.Equal(t, "U1", output.UserId)
+	assert.Equal(t, "Europe/London", output.User.Timezone)
+}
+
+func TestGetUserInfoFailsWithoutUserId(t *testing.T) {
+	event := GetUserInfo(NewMockSlack()).Handler([]byte(`{}`))
+
+	require.Equal(t, getUserInfoFailedEventDef, event.EventDef)
+	assert.Equal(t, "missing user id field", event.Payload.(GetUserInfoFail).Reason)
+}
+
+func TestLookupUserByEmail(t *testing.T) {
+	slack := NewMockSlack()
+	slack.LookupUserByEmailFunc = func(email string) (*client.User, error) {
+		return &client.User{Id: "U1", Email: email}, nil
+	}
+
+	event := LookupUserByEmail(slack).Handler([]byte(`{"email": "jdoe@example.com"}`))
+
+	require.Equal(t, lookupUserByEmailSuccessEventDef, event.EventDef)
+	assert.Equal(t, "U1", event.Payload.(LookupUserByEmailSuccess).User.Id)
+}
+
+func TestLookupUserByEmailFails(t *testing.T) {
+	slack := NewMockSlack()
+	slack.LookupUserByEmailFunc = func(email string) (*client.User, error) {
+		return nil, errors.New("cannot find user with email=nobody@example.com: users_not_found")
+	}
+
+	event := LookupUserByEmail(slack).Handler([]byte(`{"email": "nobody@example.com"}`))
+
+	require.Equal(t, lookupUserByEmailFailedEventDef, event.EventDef)
+	assert.Equal(t, "cannot find user with email=nobody@example.com: users_not_found", event.Payload.(LookupUserByEmailFail).Reason)
+}
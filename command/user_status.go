@@ -0,0 +1,146 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/ExpediaGroup/flyte-client/flyte"
+	"github.com/ExpediaGroup/flyte-slack/client"
+	"github.com/ExpediaGroup/flyte-slack/types"
+	"time"
+)
+
+var (
+	setUserStatusSuccessEventDef = flyte.EventDef{Name: "SetUserStatusSuccess"}
+	setUserStatusFailedEventDef  = flyte.EventDef{Name: "SetUserStatusFailed"}
+	getDndInfoSuccessEventDef    = flyte.EventDef{Name: "GetDndInfoSuccess"}
+	getDndInfoFailedEventDef     = flyte.EventDef{Name: "GetDndInfoFailed"}
+)
+
+type SetUserStatusInput struct {
+	// UserId is optional, status of the user token owner is set when it's empty
+	UserId      string `json:"userId"`
+	StatusText  string `json:"statusText"`
+	StatusEmoji string `json:"statusEmoji"`
+	// Expiration is either unix time in seconds or RFC3339 timestamp, status doesn't expire when it's empty
+	Expiration string `json:"expiration"`
+}
+
+type SetUserStatusSuccess struct {
+	SetUserStatusInput
+}
+
+type SetUserStatusFail struct {
+	SetUserStatusInput
+	Reason string `json:"reason"`
+}
+
+type GetDndInfoInput struct {
+	UserId string `json:"userId"`
+}
+
+type GetDndInfoSuccess struct {
+	GetDndInfoInput
+	Dnd *types.DndStatus `json:"dnd"`
+}
+
+type GetDndInfoFail struct {
+	GetDndInfoInput
+	Reason string `json:"reason"`
+}
+
+// SetUserStatus sets custom status, empty text and emoji clear it
+func SetUserStatus(slack client.Slack) flyte.Command {
+	return flyte.Command{
+		Name:         "SetUserStatus",
+		OutputEvents: []flyte.EventDef{setUserStatusSuccessEventDef, setUserStatusFailedEventDef},
+		Handler:      setUserStatusHandler(slack),
+	}
+}
+
+func setUserStatusHandler(slack client.Slack) func(json.RawMessage) flyte.Event {
+	return func(rawInput json.RawMessage) flyte.Event {
+		input := SetUserStatusInput{}
+		if err := json.Unmarshal(rawInput, &input); err != nil {
+			return flyte.NewFatalEvent(fmt.Sprintf("input is not valid: %v", err))
+		}
+
+		var expiration time.Time
+		if input.Expiration != "" {
+			t, err := parseTime("expiration", input.Expiration)
+			if err != nil {
+				return newSetUserStatusFail(input, err.Error())
+			}
+			expiration = t
+		}
+
+		if err := slack.SetUserStatus(input.UserId, input.StatusText, input.StatusEmoji, expiration); err != nil {
+			return newSetUserStatusFail(input, err.Error())
+		}
+
+		return flyte.Event{
+			EventDef: setUserStatusSuccessEventDef,
+			Payload:  SetUserStatusSuccess{SetUserStatusInput: input},
+		}
+	}
+}
+
+func newSetUserStatusFail(input SetUserStatusInput, reason string) flyte.Event {
+	return flyte.Event{
+		EventDef: setUserStatusFailedEventDef,
+		Payload:  SetUserStatusFail{SetUserStatusInput: input, Reason: reason},
+	}
+}
+
+func GetDndInfo(slack client.Slack) flyte.Command {
+	return flyte.Command{
+		Name:         "GetDndInfo",
+		OutputEvents: []flyte.EventDef{getDndInfoSuccessEventDef, getDndInfoFailedEventDef},
+		Handler:      getDndInfoHandler(slack),
+	}
+}
+
+func getDndInfoHandler(slack client.Slack) func(json.RawMessage) flyte.Event {
+	return func(rawInput json.RawMessage) flyte.Event {
+		input := GetDndInfoInput{}
+		if err := json.Unmarshal(rawInput, &input); err != nil {
+			return flyte.NewFatalEvent(fmt.Sprintf("input is not valid: %v", err))
+		}
+
+		if input.UserId == "" {
+			return newGetDndInfoFail(input, "missing user id field")
+		}
+
+		dnd, err := slack.GetDndInfo(input.UserId)
+		if err != nil {
+			return newGetDndInfoFail(input, err.Error())
+		}
+
+		return flyte.Event{
+			EventDef: getDndInfoSuccessEventDef,
+			Payload:  GetDndInfoSuccess{GetDndInfoInput: input, Dnd: dnd},
+		}
+	}
+}
+
+func newGetDndInfoFail(input GetDndInfoInput, reason string) flyte.Event {
+	return flyte.Event{
+		EventDef: getDndInfoFailedEventDef,
+		Payload:  GetDndInfoFail{GetDndInfoInput: input, Reason: reason},
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright (C) 2018 Expedia Group.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package command
+
+import (
+	"errors"
+	"github.com/ExpediaGroup/flyte-slack/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestSetUserStatus(t *testing.T) {
+	slack := NewMockSlack()
+	var expiration time.Time
+	slack.SetUserStatusFunc = func(userId, text, emoji string, exp time.Time) error {
+		expiration = exp
+		return nil
+	}
+
+	event := SetUserStatus(slack).Handler([]byte(`{"userId": "U1", "statusText": "On call until 18:00", "statusEmoji": ":pager:", "expiration": "2026-10-20T18:00:00+01:00"}`))
+
+	require.Equal(t, setUserStatusSuccessEventDef, event.EventDef)
+	assert.Equal(t, int64(1792515600), expiration.Unix())
+}
+
+func TestSetUserStatusFailsForInvalidExpiration(t *testing.T) {
+	event := SetUserStatus(NewMockSlack()).Handler([]byte(`{"userId": "U1", "statusText": "On call", "expiration": "tonight"}`))
+
+	require.Equal(t, setUserStatusFailedEventDef, event.EventDef)
+	assert.Equal(t, `expiration="tonight" is neither unix time nor RFC3339 timestamp`, event.Payload.(SetUserStatusFail).Reason)
+}
+
+func TestSetUserStatusFails(t *testing.T) {
+	slack := NewMockSlack()
+	slack.SetUserStatusFunc = func(userId, text, emoji string, exp time.Time) error {
+		return errors.New("cannot set status of user=U1: user token is not configured")
+	}
+
+	event := SetUserStatus(slack).Handler([]byte(`{"userId": "U1", "statusText": "On call"}`))
+
+	require.Equal(t, setUserStatusFailedEventDef, event.EventDef)
+	assert.Equal(t, "cannot set status of user=U1: user token is not configured", event.Payload.(SetUserStatusFail).Reason)
+}
+
+func TestGetDndInfo(t *testing.T) {
+	slack := NewMockSlack()
+	slack.GetDndInfoFunc = func(userId string) (*types.DndStatus, error) {
+		return &types.DndStatus{Active: true, SnoozeEnabled: true}, nil
+	}
+
+	event := GetDndInfo(slack).Handler([]byte(`{"userId": "U1"}`))
+
+	require.Equal(t, getDndInfoSuccessEventDef, event.EventDef)
+	assert.True(t, event.Payload.(GetDndInfoSuccess).Dnd.Active)
+}
+
+func TestGetDndInfoFailsWithoutUserId(t *testing.T) {
+	event := GetDndInfo(NewMockSlack()).Handler([]byte(`{}`))
+
+	require.Equal(t, getDndInfoFailedEventDef, event.EventDef)
+	assert.Equal(t, "missing user id field", event.Payload.(GetDndInfoFail).Reason)
+}
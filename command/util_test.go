@@ -20,11 +20,49 @@ import (
 	"github.com/ExpediaGroup/flyte-client/flyte"
 	"github.com/ExpediaGroup/flyte-slack/client"
 	"github.com/ExpediaGroup/flyte-slack/types"
+	"time"
 )
 
 type MockSlack struct {
-	SendMessageCalls    map[string][]string
-	SendRichMessageFunc func(rm client.RichMessage) (string, string, error)
+	SendMessageCalls           map[string][]string
+	SendMessageFunc            func(message, channelId, threadTimestamp string) (string, error)
+	GetPermalinkFunc           func(channelId, timestamp string) (string, error)
+	SendRichMessageFunc        func(rm client.RichMessage) (string, string, error)
+	GetConversationsFunc       func() ([]types.Conversation, error)
+	GetConversationInfoFunc    func(channelId string) (*types.Conversation, error)
+	ScheduleMessageFunc        func(message, channelId, threadTimestamp string, postAt time.Time) (string, error)
+	ListScheduledMessagesFunc  func(channelId string) ([]types.ScheduledMessage, error)
+	DeleteScheduledMessageFunc func(channelId, scheduledMessageId string) error
+	UploadFileFunc             func(f client.FileUpload) (string, string, error)
+	GetUserGroupsFunc          func() ([]types.UserGroup, error)
+	DownloadFileFunc           func(fileId string, maxSize int) (*client.DownloadedFile, error)
+	PinMessageFunc             func(channelId, timestamp string) error
+	UnpinMessageFunc           func(channelId, timestamp string) error
+	ListPinsFunc               func(channelId string) ([]types.Pin, error)
+	CreateChannelFunc          func(name string, isPrivate bool, memberIds []string) (*types.Conversation, []string, []client.UserFailure, error)
+	ArchiveChannelFunc         func(channelId string) error
+	UnarchiveChannelFunc       func(channelId string) error
+	RenameChannelFunc          func(channelId, name string) (*types.Conversation, error)
+	GetUserGroupMembersFunc    func(userGroupId string) ([]string, error)
+	InviteToChannelFunc        func(channelId string, userIds []string) ([]string, []client.UserFailure, error)
+	RemoveFromChannelFunc      func(channelId string, userIds []string) ([]string, []client.UserFailure, error)
+	SetChannelTopicFunc        func(channelId, topic string) (*types.Conversation, error)
+	SetChannelPurposeFunc      func(channelId, purpose string) (*types.Conversation, error)
+	JoinChannelFunc            func(channelId string) (*types.Conversation, error)
+	LeaveChannelFunc           func(channelId string) error
+	GetUserInfoFunc            func(userId string) (*client.User, error)
+	LookupUserByEmailFunc      func(email string) (*client.User, error)
+	LookupUserByNameFunc       func(name string) (*client.User, error)
+	GetUsersInfoFunc           func(userIds []string) ([]client.User, []client.UserFailure)
+	ListChannelMembersFunc     func(channelId string) ([]string, error)
+	GetChannelHistoryFunc      func(channelId, oldest, latest string, limit int) ([]client.MessageEvent, bool, error)
+	GetThreadRepliesFunc       func(channelId, threadTimestamp string, limit int) ([]client.MessageEvent, bool, error)
+	ListUserGroupsFunc         func(includeDisabled bool) ([]types.UserGroup, error)
+	UpdateUserGroupMembersFunc func(userGroupId string, userIds []string) (*types.UserGroup, error)
+	CreateUserGroupFunc        func(name, handle, description string) (*types.UserGroup, error)
+	DisableUserGroupFunc       func(userGroupId string) (*types.UserGroup, error)
+	SetUserStatusFunc          func(userId, text, emoji string, expiration time.Time) error
+	GetDndInfoFunc             func(userId string) (*types.DndStatus, error)
 }
 
 func NewMockSlack() *MockSlack {
@@ -34,18 +72,165 @@ func NewMockSlack() *MockSlack {
 	return m
 }
 
-func (m *MockSlack) SendMessage(message, channelId, _ string) {
+func (m *MockSlack) SendMessage(message, channelId, threadTimestamp string) (string, error) {
 	m.SendMessageCalls[channelId] = append(m.SendMessageCalls[channelId], message)
+	if m.SendMessageFunc != nil {
+		return m.SendMessageFunc(message, channelId, threadTimestamp)
+	}
+	return "", nil
 }
 
 func (m *MockSlack) SendRichMessage(rm client.RichMessage) (string, string, error) {
 	return m.SendRichMessageFunc(rm)
 }
 
+func (m *MockSlack) GetPermalink(channelId, timestamp string) (string, error) {
+	return m.GetPermalinkFunc(channelId, timestamp)
+}
+
 func (m *MockSlack) IncomingMessages() <-chan flyte.Event {
 	return make(chan flyte.Event)
 }
 
 func (m *MockSlack) GetConversations() ([]types.Conversation, error) {
+	if m.GetConversationsFunc != nil {
+		return m.GetConversationsFunc()
+	}
 	return []types.Conversation(nil), nil
 }
+
+func (m *MockSlack) GetConversationInfo(channelId string) (*types.Conversation, error) {
+	return m.GetConversationInfoFunc(channelId)
+}
+
+func (m *MockSlack) ScheduleMessage(message, channelId, threadTimestamp string, postAt time.Time) (string, error) {
+	return m.ScheduleMessageFunc(message, channelId, threadTimestamp, postAt)
+}
+
+func (m *MockSlack) ListScheduledMessages(channelId string) ([]types.ScheduledMessage, error) {
+	return m.ListScheduledMessagesFunc(channelId)
+}
+
+func (m *MockSlack) DeleteScheduledMessage(channelId, scheduledMessageId string) error {
+	return m.DeleteScheduledMessageFunc(channelId, scheduledMessageId)
+}
+
+func (m *MockSlack) UploadFile(f client.FileUpload) (string, string, error) {
+	return m.UploadFileFunc(f)
+}
+
+func (m *MockSlack) GetUserGroups() ([]types.UserGroup, error) {
+	return m.GetUserGroupsFunc()
+}
+
+func (m *MockSlack) DownloadFile(fileId string, maxSize int) (*client.DownloadedFile, error) {
+	return m.DownloadFileFunc(fileId, maxSize)
+}
+
+func (m *MockSlack) PinMessage(channelId, timestamp string) error {
+	return m.PinMessageFunc(channelId, timestamp)
+}
+
+func (m *MockSlack) UnpinMessage(channelId, timestamp string) error {
+	return m.UnpinMessageFunc(channelId, timestamp)
+}
+
+func (m *MockSlack) ListPins(channelId string) ([]types.Pin, error) {
+	return m.ListPinsFunc(channelId)
+}
+
+func (m *MockSlack) CreateChannel(name string, isPrivate bool, memberIds []string) (*types.Conversation, []string, []client.UserFailure, error) {
+	return m.CreateChannelFunc(name, isPrivate, memberIds)
+}
+
+func (m *MockSlack) ArchiveChannel(channelId string) error {
+	return m.ArchiveChannelFunc(channelId)
+}
+
+func (m *MockSlack) UnarchiveChannel(channelId string) error {
+	return m.UnarchiveChannelFunc(channelId)
+}
+
+func (m *MockSlack) RenameChannel(channelId, name string) (*types.Conversation, error) {
+	return m.RenameChannelFunc(channelId, name)
+}
+
+func (m *MockSlack) GetUserGroupMembers(userGroupId string) ([]string, error) {
+	return m.GetUserGroupMembersFunc(userGroupId)
+}
+
+func (m *MockSlack) InviteToChannel(channelId string, userIds []string) ([]string, []client.UserFailure, error) {
+	return m.InviteToChannelFunc(channelId, userIds)
+}
+
+func (m *MockSlack) RemoveFromChannel(channelId string, userIds []string) ([]string, []client.UserFailure, error) {
+	return m.RemoveFromChannelFunc(channelId, userIds)
+}
+
+func (m *MockSlack) SetChannelTopic(channelId, topic string) (*types.Conversation, error) {
+	return m.SetChannelTopicFunc(channelId, topic)
+}
+
+func (m *MockSlack) SetChannelPurpose(channelId, purpose string) (*types.Conversation, error) {
+	return m.SetChannelPurposeFunc(channelId, purpose)
+}
+
+func (m *MockSlack) JoinChannel(channelId string) (*types.Conversation, error) {
+	return m.JoinChannelFunc(channelId)
+}
+
+func (m *MockSlack) LeaveChannel(channelId string) error {
+	return m.LeaveChannelFunc(channelId)
+}
+
+func (m *MockSlack) GetUserInfo(userId string) (*client.User, error) {
+	return m.GetUserInfoFunc(userId)
+}
+
+func (m *MockSlack) LookupUserByEmail(email string) (*client.User, error) {
+	return m.LookupUserByEmailFunc(email)
+}
+
+func (m *MockSlack) LookupUserByName(name string) (*client.User, error) {
+	return m.LookupUserByNameFunc(name)
+}
+
+func (m *MockSlack) ListChannelMembers(channelId string) ([]string, error) {
+	return m.ListChannelMembersFunc(channelId)
+}
+
+func (m *MockSlack) GetChannelHistory(channelId, oldest, latest string, limit int) ([]client.MessageEvent, bool, error) {
+	return m.GetChannelHistoryFunc(channelId, oldest, latest, limit)
+}
+
+func (m *MockSlack) GetThreadReplies(channelId, threadTimestamp string, limit int) ([]client.MessageEvent, bool, error) {
+	return m.GetThreadRepliesFunc(channelId, threadTimestamp, limit)
+}
+
+func (m *MockSlack) ListUserGroups(includeDisabled bool) ([]types.UserGroup, error) {
+	return m.ListUserGroupsFunc(includeDisabled)
+}
+
+func (m *MockSlack) UpdateUserGroupMembers(userGroupId string, userIds []string) (*types.UserGroup, error) {
+	return m.UpdateUserGroupMembersFunc(userGroupId, userIds)
+}
+
+func (m *MockSlack) CreateUserGroup(name, handle, description string) (*types.UserGroup, error) {
+	return m.CreateUserGroupFunc(name, handle, description)
+}
+
+func (m *MockSlack) DisableUserGroup(userGroupId string) (*types.UserGroup, error) {
+	return m.DisableUserGroupFunc(userGroupId)
+}
+
+func (m *MockSlack) SetUserStatus(userId, text, emoji string, expiration time.Time) error {
+	return m.SetUserStatusFunc(userId, text, emoji, expiration)
+}
+
+func (m *MockSlack) GetDndInfo(userId string) (*types.DndStatus, error) {
+	return m.GetDndInfoFunc(userId)
+}
+
+func (m *MockSlack) GetUsersInfo(userIds []string) ([]client.User, []client.UserFailure) {
+	return m.GetUsersInfoFunc(userIds)
+}
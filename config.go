@@ -17,19 +17,31 @@ limitations under the License.
 package main
 
 import (
+	"fmt"
 	"github.com/ExpediaGroup/flyte-slack/cache"
+	"github.com/ExpediaGroup/flyte-slack/client"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 	"os"
+	"path"
 	"strconv"
+	"strings"
 	"time"
 )
 
 const (
 	tokenEnvKey           = "FLYTE_SLACK_TOKEN"
+	userTokenEnvKey       = "FLYTE_SLACK_USER_TOKEN" // optional user token for calls bot token can't make, e.g. SetUserStatus
 	packNameKey           = "PACK_NAME"
 	logLevelKey           = "LOGLEVEL"
 	renewConversationList = "RENEW_CONVERSATION_LIST" // how often conversation list is updated  cache (hours)
+	uploadDirKey          = "FLYTE_SLACK_UPLOAD_DIR"  // directory UploadFile command can read local files from
+	snippetThresholdKey   = "SNIPPET_THRESHOLD"       // message length above which SendMessage uploads a snippet
+	unresolvedMentionsKey = "UNRESOLVED_MENTIONS"     // "fail" or "text", what to do with mention placeholders that can't be resolved
+	downloadLimitKey      = "DOWNLOAD_SIZE_LIMIT"     // max size in bytes of files DownloadFile command fetches
+	threadContextKey      = "THREAD_CONTEXT"          // whether thread replies include root message of the thread
+	autoJoinChannelsKey   = "AUTO_JOIN_CHANNELS"      // comma separated channel names or patterns the bot joins at startup
+	historyLimitKey       = "HISTORY_LIMIT"           // max number of messages GetChannelHistory and GetThreadReplies return
 )
 
 func logLevel() zerolog.Level {
@@ -50,6 +62,89 @@ func slackToken() string {
 	return getEnv(tokenEnvKey, true)
 }
 
+func slackUserToken() string {
+	return getEnv(userTokenEnvKey, false)
+}
+
+func uploadDir() string {
+	return getEnvDefault(uploadDirKey, "")
+}
+
+func downloadSizeLimit() int {
+	dl := getEnvDefault(downloadLimitKey, "1048576")
+
+	l, err := strconv.Atoi(dl)
+	if err != nil || l <= 0 {
+		log.Fatal().Msgf("env=%s must be a positive number of bytes, got %s", downloadLimitKey, dl)
+	}
+	return l
+}
+
+func historyLimit() int {
+	hl := getEnvDefault(historyLimitKey, "1000")
+
+	l, err := strconv.Atoi(hl)
+	if err != nil || l <= 0 {
+		log.Fatal().Msgf("env=%s must be a positive number of messages, got %s", historyLimitKey, hl)
+	}
+	return l
+}
+
+func slackConfig() (*client.Config, error) {
+	st := getEnvDefault(snippetThresholdKey, "0")
+
+	t, err := strconv.Atoi(st)
+	if err != nil {
+		return nil, err
+	}
+
+	tc, err := strconv.ParseBool(getEnvDefault(threadContextKey, "false"))
+	if err != nil {
+		return nil, err
+	}
+
+	aj, err := autoJoinChannels()
+	if err != nil {
+		return nil, err
+	}
+
+	return &client.Config{
+		SnippetThreshold: t,
+		ThreadContext:    tc,
+		AutoJoinChannels: aj,
+		UserToken:        slackUserToken(),
+	}, nil
+}
+
+// autoJoinChannels parses channel names or patterns such as inc-*, leading # is optional
+func autoJoinChannels() ([]string, error) {
+	var out []string
+	for _, p := range strings.Split(getEnvDefault(autoJoinChannelsKey, ""), ",") {
+		p = strings.TrimPrefix(strings.TrimSpace(p), "#")
+		if p == "" {
+			continue
+		}
+		if _, err := path.Match(p, ""); err != nil {
+			return nil, fmt.Errorf("env=%s has invalid pattern %s: %v", autoJoinChannelsKey, p, err)
+		}
+		out = append(out, p)
+	}
+	return out, nil
+}
+
+// degradeUnresolvedMentions tells whether unresolvable mention placeholders are rendered as plain text instead of failing the command
+func degradeUnresolvedMentions() bool {
+	switch um := getEnvDefault(unresolvedMentionsKey, "fail"); um {
+	case "fail":
+		return false
+	case "text":
+		return true
+	default:
+		log.Fatal().Msgf("env=%s must be either fail or text, got %s", unresolvedMentionsKey, um)
+		return false
+	}
+}
+
 func cacheConfig() (*cache.Config, error) {
 	rc := getEnvDefault(renewConversationList, "24")
 
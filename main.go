@@ -30,13 +30,18 @@ import (
 func main() {
 	zerolog.SetGlobalLevel(logLevel())
 
-	slack := client.NewSlack(slackToken())
+	sc, err := slackConfig()
+	if err != nil {
+		log.Fatal().Err(err).Send()
+	}
+
 	cc, err := cacheConfig()
 	if err != nil {
 		log.Fatal().Err(err).Send()
 	}
 
 	cache := cache.New(cc)
+	slack := client.NewSlack(slackToken(), sc, cache)
 
 	pack := flyte.NewPackWithPolling(packDef(slack, cache), 1*time.Second)
 	pack.Start()
@@ -48,18 +53,65 @@ func main() {
 
 func packDef(slack client.Slack, cache cache.Cache) flyte.PackDef {
 	helpUrl, _ := url.Parse("https://github.com/ExpediaGroup/flyte-slack/blob/master/README.md")
+	mentions := command.NewMentionResolver(slack, cache, degradeUnresolvedMentions())
 
 	return flyte.PackDef{
 		Name:    packName(),
 		HelpURL: helpUrl,
 		Commands: []flyte.Command{
-			command.SendMessage(slack),
-			command.SendRichMessage(slack),
+			command.SendMessage(slack, mentions),
+			command.SendRichMessage(slack, mentions),
 			command.GetChannelInfo(slack, cache),
+			command.GetPermalink(slack, cache),
+			command.ScheduleMessage(slack),
+			command.ListScheduledMessages(slack),
+			command.DeleteScheduledMessage(slack),
+			command.UploadFile(slack, uploadDir()),
+			command.DownloadFile(slack, downloadSizeLimit()),
+			command.PinMessage(slack),
+			command.UnpinMessage(slack),
+			command.ListPins(slack),
+			command.CreateChannel(slack),
+			command.ArchiveChannel(slack),
+			command.UnarchiveChannel(slack),
+			command.RenameChannel(slack),
+			command.InviteToChannel(slack, cache),
+			command.RemoveFromChannel(slack, cache),
+			command.ListChannelMembers(slack, cache),
+			command.SetChannelTopic(slack, mentions),
+			command.SetChannelPurpose(slack, mentions),
+			command.JoinChannel(slack, cache),
+			command.LeaveChannel(slack, cache),
+			command.GetUserInfo(slack),
+			command.LookupUserByEmail(slack),
+			command.ListUserGroups(slack),
+			command.GetUserGroupMembers(slack, cache),
+			command.UpdateUserGroupMembers(slack, cache),
+			command.CreateUserGroup(slack),
+			command.DisableUserGroup(slack, cache),
+			command.SetUserStatus(slack),
+			command.GetDndInfo(slack),
+			command.GetChannelHistory(slack, cache, historyLimit()),
+			command.GetThreadReplies(slack, cache, historyLimit()),
 		},
 		EventDefs: []flyte.EventDef{
 			{Name: "ReceivedMessage"},
+			{Name: "AppMentioned"},
+			{Name: "DirectMessageReceived"},
 			{Name: "ReactionAdded"},
+			{Name: "MemberJoinedChannel"},
+			{Name: "MemberLeftChannel"},
+			{Name: "ChannelCreated"},
+			{Name: "ChannelRenamed"},
+			{Name: "ChannelArchived"},
+			{Name: "ChannelUnarchived"},
+			{Name: "UserJoinedWorkspace"},
+			{Name: "UserChanged"},
+			{Name: "PinAdded"},
+			{Name: "PinRemoved"},
+			{Name: "StarAdded"},
+			{Name: "StarRemoved"},
+			{Name: "UserGroupUpdated"},
 		},
 	}
 }
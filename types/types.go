@@ -3,7 +3,53 @@ package types
 
 // Conversation describes slack channel
 type Conversation struct {
-	ID    string `json:"id"`
-	Name  string `json:"name"`
-	Topic string `json:"topic"`
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Topic   string `json:"topic"`
+	Purpose string `json:"purpose"`
+	// Type is one of channel, group (private channel), im or mpim
+	Type       string `json:"type"`
+	IsArchived bool   `json:"isArchived"`
+}
+
+// ScheduledMessage describes slack message waiting to be posted
+type ScheduledMessage struct {
+	ID          string `json:"id"`
+	ChannelID   string `json:"channelId"`
+	PostAt      int    `json:"postAt"`
+	DateCreated int    `json:"dateCreated"`
+	Text        string `json:"text"`
+}
+
+// UserGroup describes slack user group (subteam)
+type UserGroup struct {
+	ID          string `json:"id"`
+	Handle      string `json:"handle"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	UserCount   int    `json:"userCount"`
+	IsDisabled  bool   `json:"isDisabled"`
+	// Users is only set when slack sends members along, e.g. in UserGroupUpdated events
+	Users []string `json:"users,omitempty"`
+}
+
+// Pin describes item pinned to a channel, either a message or a file
+type Pin struct {
+	// Type is message or file
+	Type      string `json:"type"`
+	Timestamp string `json:"timestamp"`
+	User      string `json:"user"`
+	Text      string `json:"text"`
+	FileID    string `json:"fileId"`
+}
+
+// DndStatus describes do not disturb settings of a user, times are unix time in seconds
+type DndStatus struct {
+	// Active tells whether notifications are paused right now, either by snooze or by the schedule
+	Active        bool `json:"active"`
+	DndEnabled    bool `json:"dndEnabled"`
+	NextDndStart  int  `json:"nextDndStart"`
+	NextDndEnd    int  `json:"nextDndEnd"`
+	SnoozeEnabled bool `json:"snoozeEnabled"`
+	SnoozeEnd     int  `json:"snoozeEnd"`
 }